@@ -1,17 +1,26 @@
 package cmd
 
 import (
+    "bufio"
+    "bytes"
     "encoding/base64"
     "fmt"
+    "io"
+    "net/http"
+    "os"
     "strings"
+    "unicode/utf8"
 
     "dt/internal/cliio"
     "github.com/spf13/cobra"
 )
 
 var (
-    b64url   bool
-    b64nopad bool
+    b64url    bool
+    b64nopad  bool
+    b64wrap   int
+    b64files  bool
+    b64output decodedOutput
 )
 
 func init() {
@@ -20,78 +29,276 @@ func init() {
     rootCmd.AddCommand(base64Cmd)
 }
 
+// base64Cmd is shorthand for "dt encode base64" and "dt decode base64", with --url
+// in place of --alphabet url; both go through the same streaming code.
 var base64Cmd = &cobra.Command{Use: "base64", Short: "Base64 encode/decode"}
 
 var base64EncodeCmd = &cobra.Command{
-    Use:   "encode",
+    Use:   "encode [text...|files...]",
     Short: "Base64-encode input",
+    Long: `Encodes the arguments as text, stdin when piped, or with --files the named files in
+order. Input is streamed, so files of any size use constant memory. --wrap 76 breaks
+the output into MIME-style lines.`,
+    Example: `echo -n hello | dt base64 encode
+dt base64 encode --wrap 76 -f cert.der > cert.b64`,
     RunE: func(cmd *cobra.Command, args []string) error {
-        in, err := cliio.ReadAll(args)
+        if b64wrap < 0 {
+            return fmt.Errorf("--wrap must not be negative")
+        }
+        in, closeIn, err := openInput(args, b64files)
         if err != nil {
             return err
         }
-        var enc *base64.Encoding
-        if b64url {
-            if b64nopad {
-                enc = base64.RawURLEncoding
-            } else {
-                enc = base64.URLEncoding
-            }
-        } else {
-            if b64nopad {
-                enc = base64.RawStdEncoding
-            } else {
-                enc = base64.StdEncoding
-            }
-        }
-        out := enc.EncodeToString(in)
-        fmt.Println(out)
-        return nil
+        defer closeIn()
+        return encodeStream(findEncodingScheme("base64"), in, base64Alphabet(b64url), b64nopad, b64wrap)
     },
 }
 
+// base64Encoding picks the standard or URL-safe alphabet, with or without padding.
+func base64Encoding(url, noPad bool) *base64.Encoding {
+    enc := base64.StdEncoding
+    if url {
+        enc = base64.URLEncoding
+    }
+    if noPad {
+        enc = enc.WithPadding(base64.NoPadding)
+    }
+    return enc
+}
+
 var base64DecodeCmd = &cobra.Command{
-    Use:   "decode",
+    Use:   "decode [encoded|files...]",
     Short: "Base64-decode input",
+    Long: `Decodes Base64 from the arguments, stdin, or with --files the named files. Input is
+streamed; whitespace and line breaks are ignored and padding is optional. Text is
+printed as a line; binary data (images, certificates, protobufs) is written byte for
+byte, and is only sent to a terminal with --force. Use -o to save to a file; the
+detected MIME type is reported. Text and binary are told apart by the first 512 bytes.`,
+    Example: `echo 'YXBwOnNlY3JldA==' | dt base64 decode
+pbpaste | dt base64 decode -o logo.png
+dt base64 decode -f mail-attachment.b64 -o report.pdf
+dt base64 decode --raw aGVsbG8= | xxd`,
     RunE: func(cmd *cobra.Command, args []string) error {
-        in, err := cliio.ReadAll(args)
+        in, closeIn, err := openInput(args, b64files)
         if err != nil {
             return err
         }
-        s := strings.TrimSpace(string(in))
-        // Try both raw and padded variants; URL if --url set.
-        var dec *base64.Encoding
-        try := func(e *base64.Encoding) ([]byte, error) { return e.DecodeString(s) }
-        if b64url {
-            dec = base64.RawURLEncoding
-            if out, err := try(dec); err == nil {
-                fmt.Println(string(out))
-                return nil
+        defer closeIn()
+        cmd.SilenceUsage = true
+        return decodeStream(findEncodingScheme("base64"), in, base64Alphabet(b64url), b64output)
+    },
+}
+
+// base64Alphabet maps --url to the --alphabet value of "dt encode base64".
+func base64Alphabet(url bool) string {
+    if url {
+        return "url"
+    }
+    return "std"
+}
+
+// openInput opens the input for the streaming commands: the named files in order
+// ('-' is stdin) when files is set, otherwise stdin or the arguments as text.
+func openInput(args []string, files bool) (io.Reader, func(), error) {
+    if !files {
+        r, err := cliio.Reader(args)
+        return r, func() {}, err
+    }
+    if len(args) == 0 {
+        return nil, nil, fmt.Errorf("--files needs at least one path")
+    }
+    var readers []io.Reader
+    var opened []*os.File
+    closeAll := func() {
+        for _, f := range opened {
+            f.Close()
+        }
+    }
+    for _, path := range args {
+        if path == "-" {
+            readers = append(readers, os.Stdin)
+            continue
+        }
+        f, err := os.Open(path)
+        if err != nil {
+            closeAll()
+            return nil, nil, err
+        }
+        opened = append(opened, f)
+        readers = append(readers, f)
+    }
+    return io.MultiReader(readers...), closeAll, nil
+}
+
+// base64Stream drops whitespace from r and pads the end to a multiple of four
+// characters, so one padded decoder accepts both padded and unpadded input.
+type base64Stream struct {
+    r   io.Reader
+    n   int // characters passed through
+    pad int // '=' still to emit after r is exhausted
+    eof bool
+}
+
+func (s *base64Stream) Read(p []byte) (int, error) {
+    for {
+        if s.eof {
+            n := min(s.pad, len(p))
+            if n == 0 {
+                return 0, io.EOF
             }
-            dec = base64.URLEncoding
-            if out, err := try(dec); err == nil {
-                fmt.Println(string(out))
-                return nil
+            copy(p, bytes.Repeat([]byte{'='}, n))
+            s.pad -= n
+            return n, nil
+        }
+        n, err := s.r.Read(p)
+        k := 0
+        for _, c := range p[:n] {
+            switch c {
+            case ' ', '\t', '\n', '\r', '\f', '\v':
+            default:
+                p[k] = c
+                k++
             }
-        } else {
-            dec = base64.RawStdEncoding
-            if out, err := try(dec); err == nil {
-                fmt.Println(string(out))
-                return nil
+        }
+        s.n += k
+        if err == io.EOF {
+            s.eof, s.pad = true, (4-s.n%4)%4
+        } else if err != nil {
+            return k, err
+        }
+        if k > 0 {
+            return k, nil
+        }
+    }
+}
+
+// lineWrapper breaks what is written through it into lines of width bytes; 0
+// disables wrapping. Breaks are written lazily, so output never ends in one.
+type lineWrapper struct {
+    w     io.Writer
+    width int
+    col   int
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+    if l.width <= 0 {
+        return l.w.Write(p)
+    }
+    written := 0
+    for len(p) > 0 {
+        if l.col == l.width {
+            if _, err := l.w.Write([]byte{'\n'}); err != nil {
+                return written, err
             }
-            dec = base64.StdEncoding
-            if out, err := try(dec); err == nil {
-                fmt.Println(string(out))
-                return nil
+            l.col = 0
+        }
+        n := min(len(p), l.width-l.col)
+        if _, err := l.w.Write(p[:n]); err != nil {
+            return written, err
+        }
+        l.col += n
+        written += n
+        p = p[n:]
+    }
+    return written, nil
+}
+
+// decodedOutput holds the flags that say where decoded bytes go.
+type decodedOutput struct {
+    file  string
+    raw   bool
+    force bool
+}
+
+func (o *decodedOutput) addFlags(cmd *cobra.Command) {
+    cmd.Flags().StringVarP(&o.file, "output", "o", "", "write the decoded bytes to this file")
+    cmd.Flags().BoolVar(&o.raw, "raw", false, "write the exact decoded bytes to stdout, without a trailing newline")
+    cmd.Flags().BoolVar(&o.force, "force", false, "write binary data even when stdout is a terminal")
+}
+
+// write streams r to the --output file, or to stdout: text as a line, binary
+// data (and anything with --raw) as exact bytes. The first 512 bytes decide
+// whether the data is binary; binary data is not dumped on a terminal unless
+// forced, and its detected MIME type is reported on stderr.
+func (o decodedOutput) write(r io.Reader) error {
+    br := bufio.NewReader(r)
+    head, err := br.Peek(512)
+    if err != nil && err != io.EOF {
+        return err
+    }
+    binary := looksBinary(head, len(head) == 512)
+    mime := sniffMIME(head, binary)
+    if o.file != "" {
+        f, err := os.Create(o.file)
+        if err != nil {
+            return err
+        }
+        n, err := io.Copy(f, br)
+        if cerr := f.Close(); err == nil {
+            err = cerr
+        }
+        if err != nil {
+            os.Remove(o.file)
+            return err
+        }
+        fmt.Fprintf(os.Stderr, "wrote %d bytes (%s) to %s\n", n, mime, o.file)
+        return nil
+    }
+    if binary && cliio.IsOutputTTY() && !o.force {
+        return fmt.Errorf("refusing to write binary data (%s) to the terminal; use -o <file> or --force", mime)
+    }
+    n, err := io.Copy(os.Stdout, br)
+    if err != nil {
+        return err
+    }
+    switch {
+    case binary:
+        fmt.Fprintf(os.Stderr, "decoded %d bytes (%s)\n", n, mime)
+    case !o.raw:
+        fmt.Println()
+    }
+    return nil
+}
+
+// looksBinary reports whether b is not printable UTF-8 text: invalid UTF-8, or
+// control bytes other than tab, newline, carriage return and form feed. When b
+// is a truncated sample, a rune cut off at its end is not held against it.
+func looksBinary(b []byte, truncated bool) bool {
+    if truncated {
+        for i := 1; i <= utf8.UTFMax-1 && i <= len(b); i++ {
+            if utf8.RuneStart(b[len(b)-i]) {
+                if !utf8.FullRune(b[len(b)-i:]) {
+                    b = b[:len(b)-i]
+                }
+                break
             }
         }
-        return fmt.Errorf("invalid base64 input")
-    },
+    }
+    if !utf8.Valid(b) {
+        return true
+    }
+    return bytes.ContainsFunc(b, func(r rune) bool {
+        return r < 0x20 && r != '\t' && r != '\n' && r != '\r' && r != '\f' || r == 0x7f
+    })
+}
+
+// sniffMIME returns the MIME type of data starting with head. binary is looksBinary's
+// verdict on head: sniffing calls any bytes without control characters text, so
+// for binary data a text/* guess is replaced by application/octet-stream.
+func sniffMIME(head []byte, binary bool) string {
+    mime := http.DetectContentType(head)
+    if binary && strings.HasPrefix(mime, "text/") {
+        return "application/octet-stream"
+    }
+    return mime
 }
 
 func init() {
     base64EncodeCmd.Flags().BoolVar(&b64url, "url", false, "use URL-safe encoding")
     base64EncodeCmd.Flags().BoolVar(&b64nopad, "no-pad", false, "omit '=' padding")
+    base64EncodeCmd.Flags().IntVar(&b64wrap, "wrap", 0, "break the output into lines of this many characters, e.g. 76 for MIME (0: no wrapping)")
+    base64EncodeCmd.Flags().BoolVarP(&b64files, "files", "f", false, "treat arguments as file paths ('-' is stdin)")
     base64DecodeCmd.Flags().BoolVar(&b64url, "url", false, "expect URL-safe encoding variants")
+    base64DecodeCmd.Flags().BoolVarP(&b64files, "files", "f", false, "treat arguments as file paths ('-' is stdin)")
+    b64output.addFlags(base64DecodeCmd)
 }
-
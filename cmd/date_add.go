@@ -15,6 +15,9 @@ var (
     addUTC      bool
     addFormat   string
     addLayout   string
+    addBizDays  int
+    addWeekend  string
+    addHolidays string
 )
 
 func init() {
@@ -24,25 +27,42 @@ func init() {
 var dateAddCmd = &cobra.Command{
     Use:   "add",
     Short: "Add a duration to now or a given time",
-    Long:  "Adds a Go-style duration (e.g., 90m, 1h30m, 48h) to now or a provided --from value (parsed flexibly).",
+    Long: `Adds a duration to now or a provided --from value (parsed flexibly). Durations are Go-style
+(90m, 1h30m, 48h) plus calendar units y, mo, w and d (1d, 1mo, 2w3d).
+--business-days skips weekends (--weekend) and holidays loaded from --holidays before the duration is applied.`,
     RunE: func(cmd *cobra.Command, args []string) error {
-        if strings.TrimSpace(addDuration) == "" {
-            return fmt.Errorf("--duration is required")
+        if strings.TrimSpace(addDuration) == "" && addBizDays == 0 {
+            return fmt.Errorf("--duration or --business-days is required")
         }
-        dur, err := time.ParseDuration(addDuration)
-        if err != nil {
-            return err
+        var period dateutil.Period
+        if strings.TrimSpace(addDuration) != "" {
+            p, err := dateutil.ParsePeriod(addDuration)
+            if err != nil {
+                return err
+            }
+            period = p
         }
         var base time.Time
         if strings.TrimSpace(addFrom) == "" {
-            base = time.Now()
+            base = now()
         } else {
+            var err error
             base, err = dateutil.ParseFlexible(addFrom, "", addUTC)
             if err != nil {
                 return err
             }
         }
-        t := base.Add(dur)
+        if addUTC {
+            base = base.UTC()
+        }
+        if addBizDays != 0 {
+            cal, err := loadCalendar(addWeekend, addHolidays)
+            if err != nil {
+                return err
+            }
+            base = cal.AddBusinessDays(base, addBizDays)
+        }
+        t := period.AddTo(base)
         out := dateutil.FormatTime(t, addFormat, addLayout, addUTC)
         fmt.Println(out)
         return nil
@@ -50,9 +70,36 @@ var dateAddCmd = &cobra.Command{
 }
 
 func init() {
-    dateAddCmd.Flags().StringVar(&addDuration, "duration", "", "Go duration to add, e.g., 1h30m")
+    dateAddCmd.Flags().StringVar(&addDuration, "duration", "", "duration to add, e.g., 1h30m, 1d, 1mo")
     dateAddCmd.Flags().StringVar(&addFrom, "from", "", "optional base time or epoch")
     dateAddCmd.Flags().BoolVar(&addUTC, "utc", false, "treat base/print as UTC")
-    dateAddCmd.Flags().StringVar(&addFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|layout|<Go layout>")
-    dateAddCmd.Flags().StringVar(&addLayout, "layout", "", "when --format=layout, Go time layout")
+    dateAddCmd.Flags().StringVar(&addFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|unixus|unixns|layout|<preset>|<Go layout or strftime>")
+    dateAddCmd.Flags().StringVar(&addLayout, "layout", "", "when --format=layout, Go layout, strftime pattern or preset")
+    dateAddCmd.Flags().IntVar(&addBizDays, "business-days", 0, "business days to add (negative to subtract)")
+    dateAddCmd.Flags().StringVar(&addWeekend, "weekend", "sat,sun", "non-business weekdays, e.g. fri,sat")
+    dateAddCmd.Flags().StringVar(&addHolidays, "holidays", "", "holiday calendar file (.ics, JSON or YAML/plain date list)")
+}
+
+// loadCalendar builds a business-day calendar from a weekday list and an optional holiday file.
+func loadCalendar(weekend, holidays string) (dateutil.Calendar, error) {
+    cal := dateutil.NewCalendar()
+    cal.Weekend = map[time.Weekday]bool{}
+    if strings.TrimSpace(weekend) != "" && weekend != "none" {
+        days, err := dateutil.ParseWeekdays(weekend)
+        if err != nil {
+            return cal, err
+        }
+        if len(days) == 7 {
+            return cal, fmt.Errorf("--weekend cannot cover every day of the week")
+        }
+        cal.Weekend = days
+    }
+    if holidays != "" {
+        h, err := dateutil.LoadHolidays(holidays)
+        if err != nil {
+            return cal, err
+        }
+        cal.Holidays = h
+    }
+    return cal, nil
 }
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"dt/internal/cliio"
+	"dt/internal/dateutil"
+	"dt/internal/jsonutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	annotateMode   string
+	annotateFormat string
+	annotateLayout string
+	annotateUTC    bool
+	annotateTZ     string
+	annotateUnit   string
+	annotateFields []string
+	annotateSuffix string
+)
+
+func init() {
+	dateCmd.AddCommand(dateAnnotateCmd)
+
+	dateAnnotateCmd.Flags().StringVar(&annotateMode, "mode", "append", "append the rendering after each match, or replace it: append|replace")
+	dateAnnotateCmd.Flags().StringVar(&annotateFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|unixus|unixns|layout|<preset>|<Go layout or strftime>")
+	dateAnnotateCmd.Flags().StringVar(&annotateLayout, "layout", "", "Go layout, strftime pattern or preset when --format=layout")
+	dateAnnotateCmd.Flags().BoolVar(&annotateUTC, "utc", false, "parse zone-less timestamps and print in UTC")
+	dateAnnotateCmd.Flags().StringVar(&annotateTZ, "tz", "", "print in this IANA time zone (e.g. Europe/Berlin)")
+	dateAnnotateCmd.Flags().StringVar(&annotateUnit, "unit", "auto", "epoch unit: s|ms|us|ns|auto")
+	dateAnnotateCmd.Flags().StringSliceVar(&annotateFields, "field", nil, "JSON Lines mode: field to convert (dotted paths allowed; repeatable)")
+	dateAnnotateCmd.Flags().StringVar(&annotateSuffix, "suffix", "_human", "JSON Lines append mode: suffix for the added key")
+
+	dateAnnotateCmd.RegisterFlagCompletionFunc("mode", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"append", "replace"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	dateAnnotateCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return dateutil.FormatNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+	dateAnnotateCmd.RegisterFlagCompletionFunc("unit", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return dateutil.EpochUnits, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+var dateAnnotateCmd = &cobra.Command{
+	Use:   "annotate [files...]",
+	Short: "Rewrite epochs and timestamps embedded in log lines",
+	Long: `Scans each line for epoch numbers (10-19 digits) and ISO-8601-like timestamps and
+appends a human-readable rendering after each one, or replaces it with --mode replace.
+With --field, lines are treated as JSON Lines and only the named fields are converted.
+Reads stdin when piped; otherwise reads the given files. Output is written line by line.`,
+	Example: `kubectl logs api | dt date annotate --tz Europe/Berlin
+dt date annotate --field ts --field meta.created --mode replace --utc app.jsonl`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if annotateMode != "append" && annotateMode != "replace" {
+			return fmt.Errorf("invalid mode: %s (use append or replace)", annotateMode)
+		}
+		var loc *time.Location
+		if annotateTZ != "" {
+			l, err := time.LoadLocation(annotateTZ)
+			if err != nil {
+				return fmt.Errorf("unknown time zone %q: %w", annotateTZ, err)
+			}
+			loc = l
+		}
+		render := func(t time.Time) string {
+			if loc != nil {
+				return dateutil.FormatTime(t.In(loc), annotateFormat, annotateLayout, false)
+			}
+			return dateutil.FormatTime(t, annotateFormat, annotateLayout, annotateUTC)
+		}
+		if cliio.IsInputFromPipe() {
+			return annotateStream(os.Stdin, os.Stdout, render)
+		}
+		if len(args) == 0 {
+			return errors.New("no input provided; pass files or pipe data")
+		}
+		for _, name := range args {
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			err = annotateStream(f, os.Stdout, render)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func annotateStream(in io.Reader, out io.Writer, render func(time.Time) string) error {
+	r := bufio.NewReader(in)
+	for {
+		line, err := r.ReadString('\n')
+		if len(line) > 0 {
+			body := strings.TrimRight(line, "\r\n")
+			var res string
+			if len(annotateFields) > 0 {
+				res = annotateJSONLine(body, render)
+			} else {
+				res = annotateTextLine(body, render)
+			}
+			if _, werr := io.WriteString(out, res+line[len(body):]); werr != nil {
+				return werr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func annotateTextLine(line string, render func(time.Time) string) string {
+	matches := dateutil.FindTimestamps(line, annotateUnit, annotateUTC)
+	if len(matches) == 0 {
+		return line
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(line[last:m.Start])
+		if annotateMode == "replace" {
+			b.WriteString(render(m.Time))
+		} else {
+			b.WriteString(line[m.Start:m.End])
+			b.WriteString(" (" + render(m.Time) + ")")
+		}
+		last = m.End
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// annotateJSONLine converts the --field values of a JSON object line; anything
+// that is not a JSON object is passed through untouched. Key order and number
+// literals are kept, so only the annotated fields change.
+func annotateJSONLine(line string, render func(time.Time) string) string {
+	v, err := jsonutil.DecodeOrdered(strings.NewReader(line))
+	obj, ok := v.(*jsonutil.Ordered)
+	if err != nil || !ok {
+		return line
+	}
+	changed := false
+	for _, field := range annotateFields {
+		parent, key := lookupJSONPath(obj, field)
+		if parent == nil {
+			continue
+		}
+		var t time.Time
+		var err error
+		switch v := parent.Values[key].(type) {
+		case json.Number:
+			t, err = dateutil.ParseEpoch(v.String(), annotateUnit)
+		case string:
+			t, err = dateutil.ParseFlexible(v, "", annotateUTC)
+		default:
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if annotateMode == "replace" {
+			parent.Set(key, render(t))
+		} else {
+			parent.SetAfter(key, key+annotateSuffix, render(t))
+		}
+		changed = true
+	}
+	if !changed {
+		return line
+	}
+	out, err := obj.MarshalJSON()
+	if err != nil {
+		return line
+	}
+	return string(out)
+}
+
+// lookupJSONPath resolves a dotted path and returns the object holding its last key.
+func lookupJSONPath(obj *jsonutil.Ordered, path string) (*jsonutil.Ordered, string) {
+	parts := strings.Split(path, ".")
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := obj.Values[p].(*jsonutil.Ordered)
+		if !ok {
+			return nil, ""
+		}
+		obj = next
+	}
+	key := parts[len(parts)-1]
+	if _, ok := obj.Values[key]; !ok {
+		return nil, ""
+	}
+	return obj, key
+}
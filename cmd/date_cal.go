@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"dt/internal/cliio"
+	"dt/internal/dateutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	calColor string
+	calUTC   bool
+)
+
+const (
+	calWidth     = 23 // "Wk" + 7 three-character day cells
+	ansiReverse  = "\x1b[7m"
+	ansiBoldLine = "\x1b[1;4m"
+	ansiReset    = "\x1b[0m"
+)
+
+func init() {
+	dateCmd.AddCommand(dateCalCmd)
+
+	dateCalCmd.Flags().StringVar(&calColor, "color", "auto", "highlight today and marked dates: auto|always|never")
+	dateCalCmd.Flags().BoolVar(&calUTC, "utc", false, "use UTC for today and marked dates")
+
+	dateCalCmd.RegisterFlagCompletionFunc("color", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"auto", "always", "never"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+var dateCalCmd = &cobra.Command{
+	Use:   "cal [[month] year | year-month]",
+	Short: "Print a month or year calendar with ISO week numbers",
+	Long: `Prints a Monday-first calendar with ISO week numbers and today highlighted.
+With no arguments shows the current month; "2025" shows a whole year, "2025-09" or "9 2025" one month.
+Dates piped on stdin (anything ParseFlexible or date annotate recognizes) are marked; without
+arguments the months spanning those dates are shown. Without color, today is prefixed with '>'
+and marked dates with '*'.`,
+	Example: `dt date cal
+dt date cal 2025
+git log --format=%cI | dt date cal`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var color bool
+		switch calColor {
+		case "auto":
+			color = cliio.IsOutputTTY()
+		case "always":
+			color = true
+		case "never":
+		default:
+			return fmt.Errorf("invalid color mode: %s (use auto|always|never)", calColor)
+		}
+		loc := time.Local
+		if calUTC {
+			loc = time.UTC
+		}
+		current := now().In(loc)
+
+		marks := map[string]bool{}
+		var first, last time.Time
+		if cliio.IsInputFromPipe() {
+			b, err := cliio.ReadAll(nil)
+			if err != nil {
+				return err
+			}
+			for _, line := range cliio.ReadLines(b) {
+				var found []time.Time
+				if t, err := dateutil.ParseFlexible(line, "", calUTC); err == nil {
+					found = append(found, t)
+				} else {
+					for _, m := range dateutil.FindTimestamps(line, "auto", calUTC) {
+						found = append(found, m.Time)
+					}
+				}
+				for _, t := range found {
+					t = t.In(loc)
+					marks[t.Format("2006-01-02")] = true
+					if first.IsZero() || t.Before(first) {
+						first = t
+					}
+					if last.IsZero() || t.After(last) {
+						last = t
+					}
+				}
+			}
+		}
+
+		var months []time.Time
+		switch {
+		case len(args) == 2:
+			m, err1 := strconv.Atoi(args[0])
+			y, err2 := strconv.Atoi(args[1])
+			if err1 != nil || err2 != nil || m < 1 || m > 12 {
+				return fmt.Errorf("expected <month> <year>, got %q %q", args[0], args[1])
+			}
+			months = append(months, time.Date(y, time.Month(m), 1, 0, 0, 0, 0, loc))
+		case len(args) == 1 && len(args[0]) == 4:
+			y, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("expected a year, got %q", args[0])
+			}
+			for m := 1; m <= 12; m++ {
+				months = append(months, time.Date(y, time.Month(m), 1, 0, 0, 0, 0, loc))
+			}
+		case len(args) == 1:
+			t, err := time.ParseInLocation("2006-01", args[0], loc)
+			if err != nil {
+				return fmt.Errorf("expected YYYY or YYYY-MM, got %q", args[0])
+			}
+			months = append(months, t)
+		case len(marks) > 0:
+			end := time.Date(last.Year(), last.Month(), 1, 0, 0, 0, 0, loc)
+			for m := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, loc); !m.After(end); m = m.AddDate(0, 1, 0) {
+				months = append(months, m)
+			}
+		default:
+			months = append(months, time.Date(current.Year(), current.Month(), 1, 0, 0, 0, 0, loc))
+		}
+
+		today := current.Format("2006-01-02")
+		for i := 0; i < len(months); i += 3 {
+			row := months[i:min(i+3, len(months))]
+			if i > 0 {
+				fmt.Println()
+			}
+			blocks := make([][]string, len(row))
+			for j, m := range row {
+				blocks[j] = renderMonth(m, today, marks, color)
+			}
+			for line := 0; line < len(blocks[0]); line++ {
+				parts := make([]string, len(blocks))
+				for j := range blocks {
+					parts[j] = blocks[j][line]
+				}
+				fmt.Println(strings.TrimRight(strings.Join(parts, "  "), " "))
+			}
+		}
+		return nil
+	},
+}
+
+// renderMonth returns the title, header and six week rows of a month, each calWidth columns wide.
+func renderMonth(first time.Time, today string, marks map[string]bool, color bool) []string {
+	title := first.Format("January 2006")
+	pad := (calWidth - len(title)) / 2
+	lines := []string{
+		fmt.Sprintf("%-*s", calWidth, strings.Repeat(" ", pad)+title),
+		"Wk Mo Tu We Th Fr Sa Su",
+	}
+	// back up to the Monday on or before the 1st
+	d := first.AddDate(0, 0, -((int(first.Weekday()) + 6) % 7))
+	for w := 0; w < 6; w++ {
+		var b strings.Builder
+		if d.Month() == first.Month() || d.AddDate(0, 0, 6).Month() == first.Month() {
+			_, week := d.AddDate(0, 0, 3).ISOWeek()
+			fmt.Fprintf(&b, "%2d", week)
+		} else {
+			b.WriteString("  ")
+		}
+		for i := 0; i < 7; i++ {
+			if d.Month() != first.Month() {
+				b.WriteString("   ")
+				d = d.AddDate(0, 0, 1)
+				continue
+			}
+			key := d.Format("2006-01-02")
+			day := fmt.Sprintf("%2d", d.Day())
+			switch {
+			case color && key == today:
+				b.WriteString(" " + ansiReverse + day + ansiReset)
+			case color && marks[key]:
+				b.WriteString(" " + ansiBoldLine + day + ansiReset)
+			case key == today:
+				b.WriteString(">" + day)
+			case marks[key]:
+				b.WriteString("*" + day)
+			default:
+				b.WriteString(" " + day)
+			}
+			d = d.AddDate(0, 0, 1)
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"dt/internal/dateutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffUnit     string
+	diffBizDays  bool
+	diffWeekend  string
+	diffHolidays string
+	diffUTC      bool
+)
+
+// diffUnits maps --unit values to their length.
+var diffUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+func init() {
+	dateCmd.AddCommand(dateDiffCmd)
+
+	dateDiffCmd.Flags().StringVar(&diffUnit, "unit", "", "print the difference as a number of ns|us|ms|s|m|h|d|w (default: Go duration)")
+	dateDiffCmd.Flags().BoolVar(&diffBizDays, "business-days", false, "count business days between the two dates instead")
+	dateDiffCmd.Flags().StringVar(&diffWeekend, "weekend", "sat,sun", "non-business weekdays, e.g. fri,sat")
+	dateDiffCmd.Flags().StringVar(&diffHolidays, "holidays", "", "holiday calendar file (.ics, JSON or YAML/plain date list)")
+	dateDiffCmd.Flags().BoolVar(&diffUTC, "utc", false, "parse zone-less values and compare calendar dates in UTC")
+
+	dateDiffCmd.RegisterFlagCompletionFunc("unit", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"ns", "us", "ms", "s", "m", "h", "d", "w"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+var dateDiffCmd = &cobra.Command{
+	Use:   "diff <from> [to]",
+	Short: "Show the time between two times (to defaults to now)",
+	Long: `Prints to - from as a Go duration, as a number of --unit, or, with --business-days,
+as the number of business days after from's date up to and including to's date.`,
+	Example: `dt date diff 2025-09-01T09:00:00Z 2025-09-02T10:30:00Z
+dt date diff --unit d 2025-01-01 2025-03-01
+dt date diff --business-days --holidays holidays.ics 2025-12-19 2026-01-05`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := dateutil.ParseFlexible(args[0], "", diffUTC)
+		if err != nil {
+			return err
+		}
+		to := now()
+		if len(args) == 2 {
+			if to, err = dateutil.ParseFlexible(args[1], "", diffUTC); err != nil {
+				return err
+			}
+		}
+		if diffUTC {
+			from, to = from.UTC(), to.UTC()
+		}
+		if diffBizDays {
+			cal, err := loadCalendar(diffWeekend, diffHolidays)
+			if err != nil {
+				return err
+			}
+			fmt.Println(cal.BusinessDaysBetween(from, to))
+			return nil
+		}
+		d := to.Sub(from)
+		if diffUnit == "" {
+			fmt.Println(d)
+			return nil
+		}
+		unit, ok := diffUnits[diffUnit]
+		if !ok {
+			return fmt.Errorf("unsupported unit %q (use ns|us|ms|s|m|h|d|w)", diffUnit)
+		}
+		if unit <= time.Millisecond {
+			fmt.Println(int64(d / unit))
+		} else {
+			fmt.Println(strconv.FormatFloat(float64(d)/float64(unit), 'f', -1, 64))
+		}
+		return nil
+	},
+}
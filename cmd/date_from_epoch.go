@@ -2,9 +2,7 @@ package cmd
 
 import (
     "fmt"
-    "strconv"
     "strings"
-    "time"
 
     "dt/internal/cliio"
     "dt/internal/dateutil"
@@ -15,6 +13,7 @@ var (
     fromEpochFormat string
     fromEpochLayout string
     fromEpochUTC    bool
+    fromEpochUnit   string
 )
 
 func init() {
@@ -24,6 +23,7 @@ func init() {
 var dateFromEpochCmd = &cobra.Command{
     Use:   "from-epoch [values...]",
     Short: "Convert epoch to human time",
+    Long:  "Converts Unix epochs to human time. The unit (s, ms, us, ns) is detected from the magnitude unless --unit is given; fractional and negative values are accepted.",
     RunE: func(cmd *cobra.Command, args []string) error {
         var in string
         if cliio.IsInputFromPipe() {
@@ -44,16 +44,9 @@ var dateFromEpochCmd = &cobra.Command{
             if s == "" {
                 continue
             }
-            // Detect seconds vs milliseconds
-            n, err := strconv.ParseInt(s, 10, 64)
+            t, err := dateutil.ParseEpoch(s, fromEpochUnit)
             if err != nil {
-                return fmt.Errorf("invalid epoch value: %s", s)
-            }
-            var t time.Time
-            if len(s) > 10 {
-                t = time.Unix(0, n*int64(time.Millisecond))
-            } else {
-                t = time.Unix(n, 0)
+                return err
             }
             out := dateutil.FormatTime(t, fromEpochFormat, fromEpochLayout, fromEpochUTC)
             fmt.Println(out)
@@ -63,11 +56,15 @@ var dateFromEpochCmd = &cobra.Command{
 }
 
 func init() {
-    dateFromEpochCmd.Flags().StringVar(&fromEpochFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|layout|<Go layout>")
-    dateFromEpochCmd.Flags().StringVar(&fromEpochLayout, "layout", "", "Go time layout when --format=layout")
+    dateFromEpochCmd.Flags().StringVar(&fromEpochFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|unixus|unixns|layout|<preset>|<Go layout or strftime>")
+    dateFromEpochCmd.Flags().StringVar(&fromEpochLayout, "layout", "", "Go layout, strftime pattern or preset when --format=layout")
     dateFromEpochCmd.Flags().BoolVar(&fromEpochUTC, "utc", false, "print in UTC")
+    dateFromEpochCmd.Flags().StringVar(&fromEpochUnit, "unit", "auto", "input unit: s|ms|us|ns|auto")
     dateFromEpochCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-        return []string{"rfc3339", "unix", "unixms", "layout"}, cobra.ShellCompDirectiveNoFileComp
+        return dateutil.FormatNames(), cobra.ShellCompDirectiveNoFileComp
+    })
+    dateFromEpochCmd.RegisterFlagCompletionFunc("unit", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+        return dateutil.EpochUnits, cobra.ShellCompDirectiveNoFileComp
     })
 }
 
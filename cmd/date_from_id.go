@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"dt/internal/cliio"
+	"dt/internal/dateutil"
+	"dt/internal/idutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fromIDType   string
+	fromIDEpoch  string
+	fromIDFormat string
+	fromIDLayout string
+	fromIDUTC    bool
+	fromIDJSON   bool
+)
+
+func init() {
+	dateCmd.AddCommand(dateFromIDCmd)
+
+	dateFromIDCmd.Flags().StringVar(&fromIDType, "type", "auto", "ID type: auto|uuid|ulid|ksuid|objectid|snowflake")
+	dateFromIDCmd.Flags().StringVar(&fromIDEpoch, "epoch", "twitter", "Snowflake epoch: twitter|discord|<epoch ms>|<time>")
+	dateFromIDCmd.Flags().StringVar(&fromIDFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|unixus|unixns|layout|<preset>|<Go layout or strftime>")
+	dateFromIDCmd.Flags().StringVar(&fromIDLayout, "layout", "", "Go layout, strftime pattern or preset when --format=layout")
+	dateFromIDCmd.Flags().BoolVar(&fromIDUTC, "utc", false, "print in UTC")
+	dateFromIDCmd.Flags().BoolVar(&fromIDJSON, "json", false, "print one JSON object per ID")
+
+	dateFromIDCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return idutil.Types, cobra.ShellCompDirectiveNoFileComp
+	})
+	dateFromIDCmd.RegisterFlagCompletionFunc("epoch", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"twitter", "discord"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	dateFromIDCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return dateutil.FormatNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+var dateFromIDCmd = &cobra.Command{
+	Use:   "from-id [ids...]",
+	Short: "Extract the creation time from a time-ordered ID",
+	Long: `Detects Snowflakes (Twitter/Discord layout), ULIDs, UUIDv1/v6/v7, MongoDB ObjectIDs and
+KSUIDs, and prints the embedded timestamp together with the other decoded fields.
+Snowflake epochs default to Twitter's; pass --epoch discord or a custom epoch.`,
+	Example: `dt date from-id 01ARZ3NDEKTSV4RRFFQ69G5FAV
+dt date from-id --epoch discord --utc 175928847299117063`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var in string
+		if cliio.IsInputFromPipe() {
+			b, err := cliio.ReadAll(nil)
+			if err != nil {
+				return err
+			}
+			in = string(b)
+		} else {
+			in = strings.Join(args, "\n")
+			if strings.TrimSpace(in) == "" {
+				return fmt.Errorf("no input provided")
+			}
+		}
+		epoch, err := snowflakeEpoch(fromIDEpoch)
+		if err != nil {
+			return err
+		}
+		first := true
+		for _, line := range cliio.ReadLines([]byte(in)) {
+			id := strings.TrimSpace(line)
+			if id == "" {
+				continue
+			}
+			info, err := idutil.Inspect(id, fromIDType, epoch)
+			if err != nil {
+				return err
+			}
+			if fromIDJSON {
+				out := map[string]string{"id": id, "type": info.Type}
+				if info.HasTime {
+					out["time"] = dateutil.FormatTime(info.Time, fromIDFormat, fromIDLayout, fromIDUTC)
+				}
+				for _, f := range info.Fields {
+					out[f.Name] = f.Value
+				}
+				b, err := json.Marshal(out)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
+				continue
+			}
+			if !first {
+				fmt.Println()
+			}
+			first = false
+			fmt.Printf("%-11s %s\n", "id:", id)
+			fmt.Printf("%-11s %s\n", "type:", info.Type)
+			if info.HasTime {
+				fmt.Printf("%-11s %s\n", "time:", dateutil.FormatTime(info.Time, fromIDFormat, fromIDLayout, fromIDUTC))
+			}
+			for _, f := range info.Fields {
+				fmt.Printf("%-11s %s\n", f.Name+":", f.Value)
+			}
+		}
+		return nil
+	},
+}
+
+// snowflakeEpoch resolves a named epoch, a Unix millisecond value or a parseable time.
+func snowflakeEpoch(s string) (time.Time, error) {
+	if ms, ok := idutil.SnowflakeEpochs[strings.ToLower(s)]; ok {
+		return time.UnixMilli(ms), nil
+	}
+	if t, err := dateutil.ParseEpoch(s, "ms"); err == nil {
+		return t, nil
+	}
+	t, err := dateutil.ParseFlexible(s, "", true)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --epoch %q (use twitter, discord, epoch ms or a time)", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"dt/internal/dateutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	infoLayout string
+	infoUTC    bool
+	infoZones  []string
+)
+
+func init() {
+	dateCmd.AddCommand(dateInfoCmd)
+
+	dateInfoCmd.Flags().StringVar(&infoLayout, "layout", "", "Go layout, strftime pattern or preset to parse (optional)")
+	dateInfoCmd.Flags().BoolVar(&infoUTC, "utc", false, "parse as UTC when timezone missing and describe the UTC date")
+	dateInfoCmd.Flags().StringSliceVar(&infoZones, "zones", []string{"UTC", "America/Los_Angeles", "America/New_York", "Europe/London", "Asia/Kolkata", "Asia/Tokyo"}, "IANA zones to show the instant in")
+}
+
+var dateInfoCmd = &cobra.Command{
+	Use:   "info [time]",
+	Short: "Describe a time: weekday, ISO week, quarter, epochs and other zones",
+	Long:  "Parses the time flexibly (default: now) and prints calendar facts, Unix time in every unit and the same instant in several zones.",
+	Example: `dt date info 2025-09-17T12:34:56Z
+dt date info --zones UTC,Australia/Sydney 1758112496`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		t := now()
+		if len(args) == 1 {
+			var err error
+			if t, err = dateutil.ParseFlexible(args[0], infoLayout, infoUTC); err != nil {
+				return err
+			}
+		}
+		if infoUTC {
+			t = t.UTC()
+		}
+		isoYear, isoWeek := t.ISOWeek()
+		rows := [][2]string{
+			{"time", dateutil.FormatTime(t, "rfc3339", "", false)},
+			{"weekday", t.Weekday().String()},
+			{"iso week", fmt.Sprintf("%d-W%02d-%d", isoYear, isoWeek, (int(t.Weekday())+6)%7+1)},
+			{"day of year", strconv.Itoa(t.YearDay())},
+			{"quarter", fmt.Sprintf("Q%d", (int(t.Month())-1)/3+1)},
+			{"unix", dateutil.FormatTime(t, "unix", "", false)},
+			{"unixms", dateutil.FormatTime(t, "unixms", "", false)},
+			{"unixus", dateutil.FormatTime(t, "unixus", "", false)},
+			{"unixns", dateutil.FormatTime(t, "unixns", "", false)},
+		}
+		for _, z := range infoZones {
+			z = strings.TrimSpace(z)
+			loc, err := time.LoadLocation(z)
+			if err != nil {
+				return fmt.Errorf("unknown time zone %q: %w", z, err)
+			}
+			rows = append(rows, [2]string{z, dateutil.FormatTime(t.In(loc), "rfc3339", "", false)})
+		}
+		width := 0
+		for _, r := range rows {
+			width = max(width, len(r[0])+1)
+		}
+		for _, r := range rows {
+			fmt.Printf("%-*s %s\n", width, r[0]+":", r[1])
+		}
+		return nil
+	},
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
     "fmt"
-    "time"
 
     "dt/internal/dateutil"
     "github.com/spf13/cobra"
@@ -28,7 +27,7 @@ var dateNowCmd = &cobra.Command{
     Use:   "now",
     Short: "Print current time",
     RunE: func(cmd *cobra.Command, args []string) error {
-        t := time.Now()
+        t := now()
         fmt.Println(dateutil.FormatTime(t, dateFormat, dateLayout, dateUTC))
         return nil
     },
@@ -37,13 +36,13 @@ var dateNowCmd = &cobra.Command{
 func init() {
     // shared flags on date namespace where useful
     for _, c := range []*cobra.Command{dateNowCmd} {
-        c.Flags().StringVar(&dateFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|layout|<Go layout>")
-        c.Flags().StringVar(&dateLayout, "layout", "", "when --format=layout, Go time layout to use")
+        c.Flags().StringVar(&dateFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|unixus|unixns|layout|<preset>|<Go layout or strftime>")
+        c.Flags().StringVar(&dateLayout, "layout", "", "when --format=layout, Go layout, strftime pattern or preset to use")
         c.Flags().BoolVar(&dateUTC, "utc", false, "print in UTC")
     }
     // completion for --format
     dateNowCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-        return []string{"rfc3339", "unix", "unixms", "layout"}, cobra.ShellCompDirectiveNoFileComp
+        return dateutil.FormatNames(), cobra.ShellCompDirectiveNoFileComp
     })
 }
 
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"dt/internal/dateutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rangeFrom      string
+	rangeTo        string
+	rangeStep      string
+	rangeCount     int
+	rangeInclusive bool
+	rangeWeekdays  string
+	rangeFormat    string
+	rangeLayout    string
+	rangeUTC       bool
+)
+
+func init() {
+	dateCmd.AddCommand(dateRangeCmd)
+
+	dateRangeCmd.Flags().StringVar(&rangeFrom, "from", "", "first time or epoch (required)")
+	dateRangeCmd.Flags().StringVar(&rangeTo, "to", "", "end time or epoch (exclusive unless --inclusive)")
+	dateRangeCmd.Flags().StringVar(&rangeStep, "step", "1d", "step: Go duration or calendar units y|mo|w|d, e.g. 1h, 1d, 1mo, -1w")
+	dateRangeCmd.Flags().IntVarP(&rangeCount, "count", "n", 0, "number of values to emit (instead of or in addition to --to)")
+	dateRangeCmd.Flags().BoolVar(&rangeInclusive, "inclusive", false, "include --to itself when it falls on a step")
+	dateRangeCmd.Flags().StringVar(&rangeWeekdays, "weekdays", "", "only emit these weekdays, e.g. mon-fri or sat,sun")
+	dateRangeCmd.Flags().StringVar(&rangeFormat, "format", "rfc3339", "output format: rfc3339|unix|unixms|unixus|unixns|layout|<preset>|<Go layout or strftime>")
+	dateRangeCmd.Flags().StringVar(&rangeLayout, "layout", "", "Go layout, strftime pattern or preset when --format=layout")
+	dateRangeCmd.Flags().BoolVar(&rangeUTC, "utc", false, "parse and print in UTC")
+
+	dateRangeCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return dateutil.FormatNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+var dateRangeCmd = &cobra.Command{
+	Use:   "range",
+	Short: "Generate a sequence of dates or times",
+	Long: `Emits timestamps from --from towards --to (exclusive by default) in --step increments.
+Steps accept Go durations plus calendar units (y, mo, w, d); month steps clamp to the
+end of shorter months. Use --count to stop after N values and --weekdays to filter.`,
+	Example: `dt date range --from 2025-01-01 --to 2025-02-01 --step 1d --format %Y-%m-%d
+dt date range --from 2025-01-01 --to 2025-01-02 --step 1h --utc --format 'dt=%Y-%m-%d/hr=%H'
+dt date range --from 2025-01-31 --count 3 --step 1mo --format sql
+dt date range --from 2025-09-01 --to 2025-10-01 --weekdays mon-fri --format %F`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(rangeFrom) == "" {
+			return errors.New("--from is required")
+		}
+		if strings.TrimSpace(rangeTo) == "" && rangeCount <= 0 {
+			return errors.New("--to or --count is required")
+		}
+		step, err := dateutil.ParsePeriod(rangeStep)
+		if err != nil {
+			return err
+		}
+		if step.IsZero() {
+			return errors.New("--step must not be zero")
+		}
+		from, err := dateutil.ParseFlexible(rangeFrom, "", rangeUTC)
+		if err != nil {
+			return err
+		}
+		forward := step.AddTo(from).After(from)
+		var to time.Time
+		hasTo := strings.TrimSpace(rangeTo) != ""
+		if hasTo {
+			if to, err = dateutil.ParseFlexible(rangeTo, "", rangeUTC); err != nil {
+				return err
+			}
+			if (forward && to.Before(from)) || (!forward && to.After(from)) {
+				return errors.New("--step moves away from --to")
+			}
+		}
+		var days map[time.Weekday]bool
+		if rangeWeekdays != "" {
+			if days, err = dateutil.ParseWeekdays(rangeWeekdays); err != nil {
+				return err
+			}
+		}
+
+		w := bufio.NewWriter(os.Stdout)
+		defer w.Flush()
+		emitted, misses := 0, 0
+		cycle := weekdayCycle(step)
+		for i := 0; rangeCount <= 0 || emitted < rangeCount; i++ {
+			t := step.Scale(i).AddTo(from)
+			if hasTo {
+				past := t.After(to)
+				if !forward {
+					past = t.Before(to)
+				}
+				if past || (t.Equal(to) && !rangeInclusive) {
+					break
+				}
+			}
+			if days != nil && !days[t.Weekday()] {
+				// without --to, a step that keeps missing every allowed day would loop forever
+				if misses++; !hasTo && misses > cycle {
+					return errors.New("--step never lands on one of --weekdays")
+				}
+				continue
+			}
+			misses = 0
+			if _, err := fmt.Fprintln(w, dateutil.FormatTime(t, rangeFormat, rangeLayout, rangeUTC)); err != nil {
+				return err
+			}
+			emitted++
+		}
+		return nil
+	},
+}
+
+// weekdayCycle returns the number of steps after which the weekdays that step
+// lands on repeat: whole weeks of the day and clock part, and for month steps the
+// 400-year Gregorian cycle of 4800 months, which is a whole number of weeks. A
+// run of misses longer than this will never end.
+func weekdayCycle(step dateutil.Period) int {
+	const week = int64(7 * 24 * time.Hour)
+	d := (int64(step.Days)*int64(24*time.Hour) + int64(step.Duration)) % week
+	n := int64(1)
+	if d != 0 {
+		n = week / gcd(abs64(d), week)
+	}
+	if step.Months != 0 {
+		m := 4800 / gcd(abs64(int64(step.Months)), 4800)
+		n = n / gcd(n, m) * m
+	}
+	return int(min(n, 1<<20))
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -13,6 +13,7 @@ var (
     toEpochLayout string
     toEpochUTC    bool
     toEpochMs     bool
+    toEpochUnit   string
 )
 
 func init() {
@@ -37,24 +38,35 @@ var dateToEpochCmd = &cobra.Command{
                 return fmt.Errorf("no input provided")
             }
         }
+        unit := toEpochUnit
+        if toEpochMs {
+            unit = "ms"
+        }
+        var format string
+        switch unit {
+        case "s":
+            format = "unix"
+        case "ms", "us", "ns":
+            format = "unix" + unit
+        default:
+            return fmt.Errorf("unsupported unit %q (use s|ms|us|ns)", unit)
+        }
         lines := cliio.ReadLines([]byte(in))
         for _, line := range lines {
             t, err := dateutil.ParseFlexible(line, toEpochLayout, toEpochUTC)
             if err != nil {
                 return err
             }
-            if toEpochMs {
-                fmt.Println(t.UnixMilli())
-            } else {
-                fmt.Println(t.Unix())
-            }
+            fmt.Println(dateutil.FormatTime(t, format, "", false))
         }
         return nil
     },
 }
 
 func init() {
-    dateToEpochCmd.Flags().StringVar(&toEpochLayout, "layout", "", "Go time layout to parse (optional)")
+    dateToEpochCmd.Flags().StringVar(&toEpochLayout, "layout", "", "Go layout, strftime pattern or preset to parse (optional)")
     dateToEpochCmd.Flags().BoolVar(&toEpochUTC, "utc", false, "parse as UTC when timezone missing")
-    dateToEpochCmd.Flags().BoolVar(&toEpochMs, "ms", false, "output milliseconds instead of seconds")
+    dateToEpochCmd.Flags().BoolVar(&toEpochMs, "ms", false, "output milliseconds instead of seconds (same as --unit ms)")
+    dateToEpochCmd.Flags().StringVar(&toEpochUnit, "unit", "s", "output unit: s|ms|us|ns")
+    dateToEpochCmd.MarkFlagsMutuallyExclusive("ms", "unit")
 }
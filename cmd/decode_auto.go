@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"dt/internal/cliio"
+	"dt/internal/jsonutil"
+	"dt/internal/jwtutil"
+	"github.com/spf13/cobra"
+)
+
+// decodeLayer is one encoding decode auto knows how to peel off. peel returns
+// ok=false when data is not in this encoding; label names the step in the report.
+type decodeLayer struct {
+	name string
+	peel func(data []byte) (out []byte, label string, ok bool)
+}
+
+var (
+	jwtShapeRe   = regexp.MustCompile(`^(?:[Bb]earer\s+)?[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`)
+	percentEscRe = regexp.MustCompile(`%[0-9A-Fa-f]{2}`)
+	hexRe        = regexp.MustCompile(`^(?:0[xX])?(?:[0-9A-Fa-f]{2})+$`)
+)
+
+// decodeLayers are tried in order on each pass. Hex comes before base64 because a
+// string of hex digits is usually hex even when it is also valid base64.
+var decodeLayers = []decodeLayer{
+	{name: "jwt", peel: func(data []byte) ([]byte, string, bool) {
+		s := strings.TrimSpace(string(data))
+		if !jwtShapeRe.MatchString(s) {
+			return nil, "", false
+		}
+		t, err := jwtutil.Parse(s)
+		if err != nil || t.Alg == "" {
+			return nil, "", false
+		}
+		return t.Payload, "JWT (" + t.Alg + ", payload)", true
+	}},
+	{name: "gzip", peel: func(data []byte) ([]byte, string, bool) {
+		if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+			return nil, "", false
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, "", false
+		}
+		out, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, "", false
+		}
+		return out, "gzip", true
+	}},
+	{name: "url", peel: func(data []byte) ([]byte, string, bool) {
+		s := strings.TrimSpace(string(data))
+		if !percentEscRe.MatchString(s) {
+			return nil, "", false
+		}
+		out, err := url.QueryUnescape(s)
+		if err != nil {
+			return nil, "", false
+		}
+		return []byte(out), "URL-encoded", true
+	}},
+	{name: "hex", peel: func(data []byte) ([]byte, string, bool) {
+		s := stripSpace(string(data))
+		if !hexRe.MatchString(s) {
+			return nil, "", false
+		}
+		out, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+		return out, "hex", err == nil
+	}},
+	{name: "base64", peel: func(data []byte) ([]byte, string, bool) {
+		out, err := decodeBase64(stripSpace(string(data)), false)
+		return out, "base64", err == nil
+	}},
+	{name: "base64url", peel: func(data []byte) ([]byte, string, bool) {
+		out, err := decodeBase64(stripSpace(string(data)), true)
+		return out, "base64url", err == nil
+	}},
+}
+
+const (
+	// maxDecodeLayers bounds the peeling, in case some input decodes forever.
+	maxDecodeLayers = 16
+	// minOpaqueBase64 is the shortest input decoded as base64 into unrecognised
+	// binary; shorter strings are too likely to be plain words.
+	minOpaqueBase64 = 16
+)
+
+func init() {
+	decodeCmd.AddCommand(decodeAutoCmd)
+	decodeAutoOutput.addFlags(decodeAutoCmd)
+}
+
+var decodeAutoOutput decodedOutput
+
+var decodeAutoCmd = &cobra.Command{
+	Use:   "auto [blob]",
+	Short: "Detect and peel nested encodings (base64, hex, URL, gzip, JWT)",
+	Long: `Tries each known decoder in turn (JWT, gzip, URL-encoding, hex, base64, base64url)
+and keeps peeling layers until none applies, then reports the steps on stderr, e.g.
+"base64 → gzip → JSON". JSON results are pretty-printed; binary results are handled
+as by "dt base64 decode". A layer only counts if its output is text or a recognisable
+file type, so plain words are not mistaken for base64; the one exception is an outer
+base64 layer of 16 or more characters, which may hold any binary data.`,
+	Example: `pbpaste | dt decode auto
+dt decode auto 'H4sIAAAAAAAA/6tWKi1OLVKyUkpMSVTSUSrKz0ktVrKKBnJzM/OUYmsBOl0u+iAAAAA='
+kubectl get secret app -o jsonpath='{.data.config}' | dt decode auto -o config.bin`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := cliio.ReadAll(args)
+		if err != nil {
+			return err
+		}
+		data, steps := peelLayers(data)
+		pretty, jsonErr := jsonutil.Pretty(data, 2)
+		switch {
+		case jsonErr == nil:
+			steps = append(steps, "JSON")
+		case len(steps) == 0:
+			cmd.SilenceUsage = true
+			return fmt.Errorf("no known encoding recognised")
+		case looksBinary(data, false):
+			steps = append(steps, sniffMIME(data, true))
+		default:
+			steps = append(steps, "text")
+		}
+		fmt.Fprintln(os.Stderr, strings.Join(steps, " → "))
+		cmd.SilenceUsage = true
+		if jsonErr == nil {
+			data = pretty
+		}
+		return decodeAutoOutput.write(bytes.NewReader(data))
+	},
+}
+
+// peelLayers decodes data layer by layer, returning the innermost result and the
+// label of each step taken.
+func peelLayers(data []byte) ([]byte, []string) {
+	var steps []string
+	for len(steps) < maxDecodeLayers {
+		if _, err := jsonutil.Pretty(data, 0); err == nil {
+			break
+		}
+		peeled := false
+		for _, l := range decodeLayers {
+			out, label, ok := l.peel(data)
+			if ok && len(out) > 0 && plausiblyDecoded(out) {
+				data, peeled = out, true
+				steps = append(steps, label)
+				break
+			}
+		}
+		if !peeled {
+			break
+		}
+	}
+	if len(steps) == 0 && len(stripSpace(string(data))) >= minOpaqueBase64 {
+		// The blob was handed over to be decoded, so an outer base64 layer is
+		// accepted even when what it holds is opaque binary.
+		for _, name := range []string{"base64", "base64url"} {
+			l := decodeLayers[slices.IndexFunc(decodeLayers, func(l decodeLayer) bool { return l.name == name })]
+			if out, label, ok := l.peel(data); ok && len(out) > 0 {
+				return out, []string{label}
+			}
+		}
+	}
+	return data, steps
+}
+
+// plausiblyDecoded reports whether b looks like the result of a real decoding:
+// text, or binary data of a recognisable type such as gzip or PNG.
+func plausiblyDecoded(b []byte) bool {
+	return !looksBinary(b, false) || sniffMIME(b, true) != "application/octet-stream"
+}
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"dt/internal/cliio"
+	"dt/internal/encutil"
+	"github.com/spf13/cobra"
+)
+
+var encodeCmd = &cobra.Command{
+	Use:   "encode",
+	Short: "Encode input as base64, base32, base58, base85, z85 or hex",
+}
+
+var decodeCmd = &cobra.Command{
+	Use:   "decode",
+	Short: "Decode base64, base32, base58, base85, z85 or hex input",
+}
+
+// encodingScheme is a text encoding offered by encode and decode.
+type encodingScheme struct {
+	name      string
+	short     string
+	alphabets []string // values accepted by --alphabet; the first is the default
+	padded    bool     // whether --no-pad applies
+	encode    func(b []byte, alphabet string, noPad bool) (string, error)
+	// decode receives the input with all whitespace removed and accepts padded
+	// and unpadded forms alike.
+	decode func(s, alphabet string) ([]byte, error)
+	// encoder and decoder, when set, replace encode and decode with streams, so
+	// input of any size uses constant memory and --files is offered.
+	encoder func(w io.Writer, alphabet string, noPad bool) io.WriteCloser
+	decoder func(r io.Reader, alphabet string) io.Reader
+}
+
+var encodingSchemes = []encodingScheme{
+	{
+		name: "base64", short: "Base64 (RFC 4648), standard or URL-safe alphabet",
+		alphabets: []string{"std", "url"}, padded: true,
+		encoder: func(w io.Writer, alphabet string, noPad bool) io.WriteCloser {
+			return base64.NewEncoder(base64Encoding(alphabet == "url", noPad), w)
+		},
+		decoder: func(r io.Reader, alphabet string) io.Reader {
+			enc := base64.StdEncoding
+			if alphabet == "url" {
+				enc = base64.URLEncoding
+			}
+			return base64.NewDecoder(enc, &base64Stream{r: r})
+		},
+	},
+	{
+		name: "base32", short: "Base32 (RFC 4648), e.g. TOTP secrets; std or extended hex alphabet",
+		alphabets: []string{"std", "hex"}, padded: true,
+		encode: func(b []byte, alphabet string, noPad bool) (string, error) {
+			enc := base32Encoding(alphabet)
+			if noPad {
+				enc = enc.WithPadding(base32.NoPadding)
+			}
+			return enc.EncodeToString(b), nil
+		},
+		decode: func(s, alphabet string) ([]byte, error) {
+			s = strings.ToUpper(strings.TrimRight(s, "="))
+			out, err := base32Encoding(alphabet).WithPadding(base32.NoPadding).DecodeString(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid base32 input: %w", err)
+			}
+			return out, nil
+		},
+	},
+	{
+		name: "base58", short: "Base58 as in Bitcoin addresses and short IDs; bitcoin, flickr or ripple alphabet",
+		alphabets: []string{"bitcoin", "flickr", "ripple"},
+		encode: func(b []byte, alphabet string, _ bool) (string, error) {
+			return encutil.Base58EncodeWith(b, encutil.Base58Alphabets[alphabet]), nil
+		},
+		decode: func(s, alphabet string) ([]byte, error) {
+			return encutil.Base58DecodeWith(s, encutil.Base58Alphabets[alphabet])
+		},
+	},
+	{
+		name: "ascii85", short: "Ascii85 as in PostScript and PDF; adobe adds the <~ ~> delimiters",
+		alphabets: []string{"btoa", "adobe"},
+		encode: func(b []byte, alphabet string, _ bool) (string, error) {
+			return encutil.Ascii85Encode(b, alphabet == "adobe"), nil
+		},
+		decode: func(s, _ string) ([]byte, error) { return encutil.Ascii85Decode(s) },
+	},
+	{
+		name: "base85", short: "Base85 (RFC 1924) as in git binary patches and Python's b85encode",
+		encode: func(b []byte, _ string, _ bool) (string, error) { return encutil.Base85Encode(b), nil },
+		decode: func(s, _ string) ([]byte, error) { return encutil.Base85Decode(s) },
+	},
+	{
+		name: "z85", short: "ZeroMQ Z85; input must be a multiple of 4 bytes",
+		encode: func(b []byte, _ string, _ bool) (string, error) { return encutil.Z85Encode(b) },
+		decode: func(s, _ string) ([]byte, error) { return encutil.Z85Decode(s) },
+	},
+	{
+		name: "hex", short: "Hexadecimal, lower or upper case; decoding accepts a 0x prefix and ':' separators",
+		alphabets: []string{"lower", "upper"},
+		encode: func(b []byte, alphabet string, _ bool) (string, error) {
+			if alphabet == "upper" {
+				return strings.ToUpper(hex.EncodeToString(b)), nil
+			}
+			return hex.EncodeToString(b), nil
+		},
+		decode: func(s, _ string) ([]byte, error) {
+			s = strings.ReplaceAll(s, ":", "")
+			if len(s) > 1 && (s[:2] == "0x" || s[:2] == "0X") {
+				s = s[2:]
+			}
+			return hex.DecodeString(s)
+		},
+	},
+}
+
+func init() {
+	for _, s := range encodingSchemes {
+		encodeCmd.AddCommand(newEncodeCommand(s))
+		decodeCmd.AddCommand(newDecodeCommand(s))
+	}
+	rootCmd.AddCommand(encodeCmd)
+	rootCmd.AddCommand(decodeCmd)
+}
+
+// base32Encoding picks the standard or extended hex alphabet.
+func base32Encoding(alphabet string) *base32.Encoding {
+	if alphabet == "hex" {
+		return base32.HexEncoding
+	}
+	return base32.StdEncoding
+}
+
+// decodeBase64 tries the unpadded and then the padded form of the standard or
+// URL-safe alphabet.
+func decodeBase64(s string, url bool) ([]byte, error) {
+	encs := []*base64.Encoding{base64.RawStdEncoding, base64.StdEncoding}
+	if url {
+		encs = []*base64.Encoding{base64.RawURLEncoding, base64.URLEncoding}
+	}
+	for _, e := range encs {
+		if out, err := e.DecodeString(s); err == nil {
+			return out, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid base64 input")
+}
+
+// findEncodingScheme looks a scheme up by name.
+func findEncodingScheme(name string) encodingScheme {
+	i := slices.IndexFunc(encodingSchemes, func(s encodingScheme) bool { return s.name == name })
+	return encodingSchemes[i]
+}
+
+// encodeStream encodes in with a streaming scheme and prints the result as one
+// line, broken every wrap characters.
+func encodeStream(s encodingScheme, in io.Reader, alphabet string, noPad bool, wrap int) error {
+	out := bufio.NewWriter(os.Stdout)
+	enc := s.encoder(&lineWrapper{w: out, width: wrap}, alphabet, noPad)
+	if _, err := io.Copy(enc, in); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	return out.Flush()
+}
+
+// decodeStream decodes in with a streaming scheme into output.
+func decodeStream(s encodingScheme, in io.Reader, alphabet string, output decodedOutput) error {
+	err := output.write(s.decoder(in, alphabet))
+	var corrupt base64.CorruptInputError
+	if errors.As(err, &corrupt) {
+		return fmt.Errorf("invalid %s input: %w", s.name, err)
+	}
+	return err
+}
+
+// addAlphabetFlag registers --alphabet for schemes that have variants.
+func addAlphabetFlag(cmd *cobra.Command, s encodingScheme, alphabet *string) {
+	if len(s.alphabets) == 0 {
+		return
+	}
+	cmd.Flags().StringVar(alphabet, "alphabet", s.alphabets[0], "alphabet variant: "+strings.Join(s.alphabets, "|"))
+	cmd.RegisterFlagCompletionFunc("alphabet", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return s.alphabets, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// checkAlphabet rejects --alphabet values the scheme does not offer.
+func checkAlphabet(s encodingScheme, alphabet string) error {
+	if len(s.alphabets) > 0 && !slices.Contains(s.alphabets, alphabet) {
+		return fmt.Errorf("unknown %s alphabet %q (use %s)", s.name, alphabet, strings.Join(s.alphabets, "|"))
+	}
+	return nil
+}
+
+func newEncodeCommand(s encodingScheme) *cobra.Command {
+	var alphabet string
+	var noPad, files bool
+	var wrap int
+	cmd := &cobra.Command{
+		Use:     s.name + " [text...]",
+		Short:   s.short,
+		Long:    "Encodes the arguments as text, or stdin when piped." + streamNote(s.encoder != nil),
+		Example: fmt.Sprintf("echo -n hello | dt encode %[1]s\ndt encode %[1]s --wrap 76 < cert.der", s.name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAlphabet(s, alphabet); err != nil {
+				return err
+			}
+			if wrap < 0 {
+				return fmt.Errorf("--wrap must not be negative")
+			}
+			if s.encoder != nil {
+				in, closeIn, err := openInput(args, files)
+				if err != nil {
+					return err
+				}
+				defer closeIn()
+				return encodeStream(s, in, alphabet, noPad, wrap)
+			}
+			in, err := cliio.ReadAll(args)
+			if err != nil {
+				return err
+			}
+			out, err := s.encode(in, alphabet, noPad)
+			if err != nil {
+				return err
+			}
+			fmt.Println(wrapLines(out, wrap))
+			return nil
+		},
+	}
+	addAlphabetFlag(cmd, s, &alphabet)
+	if s.padded {
+		cmd.Flags().BoolVar(&noPad, "no-pad", false, "omit '=' padding")
+	}
+	cmd.Flags().IntVar(&wrap, "wrap", 0, "break the output into lines of this many characters (0: no wrapping)")
+	if s.encoder != nil {
+		cmd.Flags().BoolVarP(&files, "files", "f", false, "treat arguments as file paths ('-' is stdin)")
+	}
+	return cmd
+}
+
+func newDecodeCommand(s encodingScheme) *cobra.Command {
+	var alphabet string
+	var files bool
+	var output decodedOutput
+	cmd := &cobra.Command{
+		Use:   s.name + " [encoded]",
+		Short: s.short,
+		Long: `Decodes the argument, or stdin when piped. Whitespace and line breaks are ignored,
+so wrapped output can be pasted back as is; padding is optional. Binary results are
+written byte for byte and only sent to a terminal with --force; -o saves to a file.` + streamNote(s.decoder != nil),
+		Example: fmt.Sprintf("dt encode %[1]s hello | dt decode %[1]s", s.name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkAlphabet(s, alphabet); err != nil {
+				return err
+			}
+			if s.decoder != nil {
+				in, closeIn, err := openInput(args, files)
+				if err != nil {
+					return err
+				}
+				defer closeIn()
+				cmd.SilenceUsage = true
+				return decodeStream(s, in, alphabet, output)
+			}
+			in, err := cliio.ReadAll(args)
+			if err != nil {
+				return err
+			}
+			out, err := s.decode(stripSpace(string(in)), alphabet)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+			return output.write(bytes.NewReader(out))
+		},
+	}
+	addAlphabetFlag(cmd, s, &alphabet)
+	output.addFlags(cmd)
+	if s.decoder != nil {
+		cmd.Flags().BoolVarP(&files, "files", "f", false, "treat arguments as file paths ('-' is stdin)")
+	}
+	return cmd
+}
+
+// streamNote is the help text added for schemes that stream their input.
+func streamNote(streams bool) string {
+	if !streams {
+		return ""
+	}
+	return "\nInput is streamed, so data of any size uses constant memory; --files reads the\nnamed files in order instead ('-' is stdin)."
+}
+
+// wrapLines inserts a newline every width characters; width 0 leaves s alone.
+func wrapLines(s string, width int) string {
+	if width <= 0 || len(s) <= width {
+		return s
+	}
+	var b strings.Builder
+	for len(s) > width {
+		b.WriteString(s[:width])
+		b.WriteByte('\n')
+		s = s[width:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// stripSpace removes all whitespace, including line breaks inside wrapped input.
+func stripSpace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
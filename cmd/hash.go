@@ -5,14 +5,24 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"hash/fnv"
+	"io"
+	"os"
+	"runtime"
 	"strings"
 
 	"dt/internal/cliio"
+	"dt/internal/hashutil"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -21,47 +31,294 @@ var hashCmd = &cobra.Command{
 	Short: "Generate digests with common hashing algorithms",
 }
 
+// hashAlgorithm is a digest offered by hash and, unless it is a checksum, by hmac.
+type hashAlgorithm struct {
+	name     string
+	short    string
+	factory  func() hash.Hash
+	checksum bool                        // non-cryptographic: fine for cache keys, not for integrity against attackers
+	seeded   func(seed uint64) hash.Hash // set for algorithms that take a seed
+}
+
+var hashAlgorithms = []hashAlgorithm{
+	{name: "md5", short: "MD5 digest (broken; legacy use only)", factory: md5.New},
+	{name: "sha1", short: "SHA-1 digest (broken; legacy use only)", factory: sha1.New},
+	{name: "sha224", short: "SHA-224 digest", factory: sha256.New224},
+	{name: "sha256", short: "SHA-256 digest", factory: sha256.New},
+	{name: "sha384", short: "SHA-384 digest", factory: sha512.New384},
+	{name: "sha512", short: "SHA-512 digest", factory: sha512.New},
+	{name: "sha3-256", short: "SHA3-256 digest", factory: func() hash.Hash { return sha3.New256() }},
+	{name: "sha3-512", short: "SHA3-512 digest", factory: func() hash.Hash { return sha3.New512() }},
+	{name: "blake2b-256", short: "BLAKE2b-256 digest", factory: func() hash.Hash { h, _ := blake2b.New256(nil); return h }},
+	{name: "blake2b-512", short: "BLAKE2b-512 digest", factory: func() hash.Hash { h, _ := blake2b.New512(nil); return h }},
+	{name: "blake2s-256", short: "BLAKE2s-256 digest", factory: func() hash.Hash { h, _ := blake2s.New256(nil); return h }},
+	{name: "blake3", short: "BLAKE3 digest (256-bit)", factory: func() hash.Hash { return hashutil.NewBLAKE3(32) }},
+	{name: "crc32", short: "CRC-32 (IEEE, as in zip and gzip)", factory: func() hash.Hash { return crc32.NewIEEE() }, checksum: true},
+	{name: "crc32c", short: "CRC-32C (Castagnoli, as in iSCSI and ext4)", factory: func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) }, checksum: true},
+	{name: "crc64", short: "CRC-64 (ECMA-182, as in xz)", factory: func() hash.Hash { return crc64.New(crc64.MakeTable(crc64.ECMA)) }, checksum: true},
+	{name: "fnv1a-32", short: "FNV-1a 32-bit", factory: func() hash.Hash { return fnv.New32a() }, checksum: true},
+	{name: "fnv1a-64", short: "FNV-1a 64-bit", factory: func() hash.Hash { return fnv.New64a() }, checksum: true},
+	{name: "xxhash64", short: "xxHash XXH64", factory: func() hash.Hash { return hashutil.NewXXH64(0) }, checksum: true,
+		seeded: func(seed uint64) hash.Hash { return hashutil.NewXXH64(seed) }},
+	{name: "murmur3-32", short: "MurmurHash3 x86_32", factory: func() hash.Hash { return hashutil.NewMurmur3_32(0) }, checksum: true,
+		seeded: func(seed uint64) hash.Hash { return hashutil.NewMurmur3_32(uint32(seed)) }},
+	{name: "murmur3-128", short: "MurmurHash3 x64_128", factory: func() hash.Hash { return hashutil.NewMurmur3_128(0) }, checksum: true,
+		seeded: func(seed uint64) hash.Hash { return hashutil.NewMurmur3_128(uint32(seed)) }},
+}
+
 func init() {
-	hashCmd.AddCommand(newHashCommand("md5", "MD5 digest", func() hash.Hash { return md5.New() }))
-	hashCmd.AddCommand(newHashCommand("sha1", "SHA-1 digest", func() hash.Hash { return sha1.New() }))
-	hashCmd.AddCommand(newHashCommand("sha256", "SHA-256 digest", func() hash.Hash { return sha256.New() }))
-	hashCmd.AddCommand(newHashCommand("sha512", "SHA-512 digest", func() hash.Hash { return sha512.New() }))
-	hashCmd.AddCommand(newHashCommand("sha3-256", "SHA3-256 digest", func() hash.Hash { return sha3.New256() }))
-	hashCmd.AddCommand(newHashCommand("sha3-512", "SHA3-512 digest", func() hash.Hash { return sha3.New512() }))
+	for _, a := range hashAlgorithms {
+		hashCmd.AddCommand(newHashCommand(a))
+	}
+	hashCmd.AddCommand(hashListCmd)
 	rootCmd.AddCommand(hashCmd)
 }
 
-func newHashCommand(name, short string, factory func() hash.Hash) *cobra.Command {
+var hashListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the supported hash algorithms",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, a := range hashAlgorithms {
+			kind := "cryptographic"
+			if a.checksum {
+				kind = "checksum"
+			}
+			if a.seeded != nil {
+				kind += ", seedable"
+			}
+			fmt.Printf("%-12s %4d  %-23s %s\n", a.name, a.factory().Size()*8, kind, a.short)
+		}
+		return nil
+	},
+}
+
+// findHashAlgorithm looks an algorithm up by name.
+func findHashAlgorithm(name string) (hashAlgorithm, bool) {
+	for _, a := range hashAlgorithms {
+		if strings.EqualFold(a.name, name) {
+			return a, true
+		}
+	}
+	return hashAlgorithm{}, false
+}
+
+// digestEncodings lists the output encodings accepted by --encoding.
+var digestEncodings = []string{"hex", "hex-upper", "base64", "base64url", "base32", "raw"}
+
+// encodeDigest renders sum in one of digestEncodings. base64url is unpadded, as in
+// JWTs; "raw" returns the digest bytes unchanged.
+func encodeDigest(sum []byte, encoding string) (string, error) {
+	switch strings.ToLower(encoding) {
+	case "hex":
+		return hex.EncodeToString(sum), nil
+	case "hex-upper":
+		return strings.ToUpper(hex.EncodeToString(sum)), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(sum), nil
+	case "base64url":
+		return base64.RawURLEncoding.EncodeToString(sum), nil
+	case "base32":
+		return base32.StdEncoding.EncodeToString(sum), nil
+	case "raw":
+		return string(sum), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q (use %s)", encoding, strings.Join(digestEncodings, "|"))
+	}
+}
+
+// printDigest writes sum to stdout: raw digests as bare bytes, everything else as a line.
+func printDigest(sum []byte, encoding string) error {
+	out, err := encodeDigest(sum, encoding)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(encoding, "raw") {
+		_, err = os.Stdout.WriteString(out)
+		return err
+	}
+	fmt.Println(out)
+	return nil
+}
+
+// digestRecipe is how input becomes a digest: an optional salt before or after the
+// data, then iterations-1 further rounds that each hash the previous raw digest.
+type digestRecipe struct {
+	salt       []byte
+	saltPrefix bool
+	iterations int
+}
+
+// start feeds a prefix salt to h before the data.
+func (r digestRecipe) start(h hash.Hash) {
+	if r.saltPrefix {
+		h.Write(r.salt)
+	}
+}
+
+// finish feeds a suffix salt to h, then applies the extra rounds.
+func (r digestRecipe) finish(h hash.Hash) []byte {
+	if !r.saltPrefix {
+		h.Write(r.salt)
+	}
+	sum := h.Sum(nil)
+	for i := 1; i < r.iterations; i++ {
+		h.Reset()
+		h.Write(sum)
+		sum = h.Sum(sum[:0])
+	}
+	return sum
+}
+
+// decodeSalt turns the --salt value into bytes according to --salt-encoding.
+func decodeSalt(value, encoding string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "utf8", "utf-8":
+		return []byte(value), nil
+	case "hex":
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex salt: %w", err)
+		}
+		return b, nil
+	case "base64":
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			b, err = base64.RawStdEncoding.DecodeString(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 salt: %w", err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unsupported salt encoding %q (use utf8|hex|base64)", encoding)
+	}
+}
+
+func newHashCommand(a hashAlgorithm) *cobra.Command {
+	name, short, factory := a.name, a.short, a.factory
+	var hashSeed uint64
 	var encoding string
-	var salt string
+	var salt, saltPosition, saltEncoding string
+	var iterations int
+	var files, recursive, progress bool
+	var check string
+	var jobs int
+	var also []string
 	cmd := &cobra.Command{
-		Use:   name,
+		Use:   name + " [text...|files...]",
 		Short: short,
+		Long: `Hashes the arguments as text, or stdin when piped. With --files (or -r) the arguments
+are paths: files are streamed and printed as sha256sum-style "<digest>  <path>" lines,
+and directories are walked with -r. --check verifies such a list like coreutils.
+Files are hashed by --jobs workers, but results are always printed in input order.
+--also adds algorithms computed in the same read pass, printed as "SHA256 (path) = ..." lines.
+For legacy schemes, --salt can be placed before or after the data and given as hex or
+base64, and --iterations re-hashes the raw digest that many times in total.`,
+		Example: fmt.Sprintf(`echo -n hello | dt hash %[1]s
+dt hash %[1]s -r dist/ > SUMS
+dt hash %[1]s --check SUMS
+dt hash %[1]s -r --also md5 --jobs 8 --progress images/`, name),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			data, err := cliio.ReadAll(args)
+			if cmd.Flags().Changed("hash-seed") {
+				factory = func() hash.Hash { return a.seeded(hashSeed) }
+			} else {
+				factory = a.factory
+			}
+			recipe, err := newDigestRecipe(salt, saltEncoding, saltPosition, iterations)
 			if err != nil {
 				return err
 			}
-			if salt != "" {
-				data = append(data, []byte(salt)...)
+			pool := hashPool{algos: []hashAlgorithm{{name: name, short: short, factory: factory}}, recipe: recipe, jobs: jobs, progress: progress}
+			for _, extra := range also {
+				more, ok := findHashAlgorithm(extra)
+				if !ok {
+					return fmt.Errorf("unknown algorithm %q in --also", extra)
+				}
+				if more.seeded != nil && cmd.Flags().Changed("hash-seed") {
+					more.factory = func() hash.Hash { return more.seeded(hashSeed) }
+				}
+				pool.algos = append(pool.algos, more)
 			}
-			h := factory()
-			if _, err := h.Write(data); err != nil {
-				return err
+			if check != "" {
+				if len(also) > 0 {
+					return fmt.Errorf("--also cannot be combined with --check")
+				}
+				return checkSums(cmd, check, pool)
+			}
+			if files || recursive {
+				if strings.EqualFold(encoding, "raw") {
+					return fmt.Errorf("--encoding raw prints a single digest; it cannot be used with --files")
+				}
+				return hashFiles(args, recursive, pool, encoding)
 			}
-			sum := h.Sum(nil)
-			switch strings.ToLower(encoding) {
-			case "hex":
-				fmt.Println(hex.EncodeToString(sum))
-			case "base64":
-				fmt.Println(base64.StdEncoding.EncodeToString(sum))
-			default:
-				return fmt.Errorf("unsupported encoding %q (use hex or base64)", encoding)
+			if len(also) > 0 {
+				return fmt.Errorf("--also requires --files or -r")
 			}
-			return nil
+			h := factory()
+			recipe.start(h)
+			if cliio.IsInputFromPipe() {
+				if _, err := io.Copy(h, os.Stdin); err != nil {
+					return err
+				}
+			} else {
+				data, err := cliio.ReadAll(args)
+				if err != nil {
+					return err
+				}
+				h.Write(data)
+			}
+			return printDigest(recipe.finish(h), encoding)
 		},
 	}
-	cmd.Flags().StringVar(&encoding, "encoding", "hex", "output encoding (hex|base64)")
-	cmd.Flags().StringVar(&salt, "salt", "", "append salt to the input before hashing")
+	cmd.Flags().StringVar(&encoding, "encoding", "hex", "output encoding ("+strings.Join(digestEncodings, "|")+")")
+	cmd.Flags().StringVar(&salt, "salt", "", "salt to mix into the input before hashing")
+	cmd.Flags().StringVar(&saltPosition, "salt-position", "suffix", "where the salt goes: prefix|suffix")
+	cmd.Flags().StringVar(&saltEncoding, "salt-encoding", "utf8", "how --salt is written: utf8|hex|base64")
+	cmd.Flags().IntVar(&iterations, "iterations", 1, "total hashing rounds; each round after the first hashes the previous raw digest")
+	cmd.Flags().BoolVarP(&files, "files", "f", false, "treat arguments as file paths ('-' is stdin)")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "hash files in directories recursively (implies --files)")
+	cmd.Flags().StringVarP(&check, "check", "c", "", "verify the files listed in a checksum file ('-' for stdin)")
+	cmd.Flags().IntVarP(&jobs, "jobs", "j", runtime.NumCPU(), "number of files to hash concurrently")
+	cmd.Flags().BoolVar(&progress, "progress", false, "report files and bytes hashed on stderr")
+	cmd.Flags().StringSliceVar(&also, "also", nil, "further algorithms to compute in the same pass, e.g. md5,sha1")
+	if a.seeded != nil {
+		cmd.Flags().Uint64Var(&hashSeed, "hash-seed", 0, "seed for the hash function and seedable --also algorithms (also used with --check)")
+	}
+	cmd.RegisterFlagCompletionFunc("encoding", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return digestEncodings, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("salt-position", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"prefix", "suffix"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("salt-encoding", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"utf8", "hex", "base64"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.RegisterFlagCompletionFunc("also", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names := make([]string, 0, len(hashAlgorithms))
+		for _, a := range hashAlgorithms {
+			names = append(names, a.name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
 	return cmd
 }
+
+// newDigestRecipe validates the salt and iteration flags.
+func newDigestRecipe(salt, saltEncoding, saltPosition string, iterations int) (digestRecipe, error) {
+	b, err := decodeSalt(salt, saltEncoding)
+	if err != nil {
+		return digestRecipe{}, err
+	}
+	if iterations < 1 {
+		return digestRecipe{}, fmt.Errorf("--iterations must be at least 1, got %d", iterations)
+	}
+	r := digestRecipe{salt: b, iterations: iterations}
+	switch strings.ToLower(saltPosition) {
+	case "prefix":
+		r.saltPrefix = true
+	case "suffix":
+	default:
+		return digestRecipe{}, fmt.Errorf("unsupported salt position %q (use prefix|suffix)", saltPosition)
+	}
+	return r, nil
+}
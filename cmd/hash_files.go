@@ -0,0 +1,371 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// collectFiles expands the path arguments into regular files, walking directories
+// in lexical order when recursive is set. "-" stands for stdin.
+func collectFiles(args []string, recursive bool) ([]string, error) {
+	if len(args) == 0 {
+		return []string{"-"}, nil
+	}
+	var out []string
+	for _, arg := range args {
+		if arg == "-" {
+			out = append(out, arg)
+			continue
+		}
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			out = append(out, arg)
+			continue
+		}
+		if !recursive {
+			return nil, fmt.Errorf("%s: is a directory (use -r)", arg)
+		}
+		err = filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Type().IsRegular() {
+				out = append(out, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// hashFile streams path (or stdin for "-") through every hash in hs at once,
+// salted and iterated by recipe, adding the bytes read to counted.
+func hashFile(path string, hs []hash.Hash, recipe digestRecipe, counted *atomic.Int64) ([][]byte, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	ws := make([]io.Writer, len(hs))
+	for i, h := range hs {
+		recipe.start(h)
+		ws[i] = h
+	}
+	n, err := io.Copy(io.MultiWriter(ws...), r)
+	counted.Add(n)
+	if err != nil {
+		return nil, err
+	}
+	sums := make([][]byte, len(hs))
+	for i, h := range hs {
+		sums[i] = recipe.finish(h)
+	}
+	return sums, nil
+}
+
+// hashPool hashes files with a bounded number of workers. Each worker streams one
+// file at a time, so memory stays flat however large the files are.
+type hashPool struct {
+	algos    []hashAlgorithm
+	recipe   digestRecipe
+	jobs     int
+	progress bool
+}
+
+// run hashes paths and calls emit in input order as soon as each result and all
+// results before it are ready, so output is stable regardless of scheduling.
+func (p hashPool) run(paths []string, emit func(i int, sums [][]byte, err error) error) error {
+	type result struct {
+		i    int
+		sums [][]byte
+		err  error
+	}
+	jobs := min(max(p.jobs, 1), max(len(paths), 1))
+	indexes := make(chan int)
+	results := make(chan result, jobs)
+	var counted atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				hs := make([]hash.Hash, len(p.algos))
+				for j, a := range p.algos {
+					hs[j] = a.factory()
+				}
+				sums, err := hashFile(paths[i], hs, p.recipe, &counted)
+				results <- result{i, sums, err}
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(indexes)
+		for i := range paths {
+			select {
+			case indexes <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var ticker <-chan time.Time
+	if p.progress {
+		t := time.NewTicker(200 * time.Millisecond)
+		defer t.Stop()
+		ticker = t.C
+	}
+	pending := map[int]result{}
+	next, finished := 0, 0
+	var emitErr error
+	report := func() {
+		fmt.Fprintf(os.Stderr, "\rhashed %d/%d files, %s", finished, len(paths), formatBytes(counted.Load()))
+	}
+	for results != nil {
+		select {
+		case r, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			finished++
+			pending[r.i] = r
+			for emitErr == nil {
+				r, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				if emitErr = emit(r.i, r.sums, r.err); emitErr != nil {
+					close(done)
+				}
+			}
+		case <-ticker:
+			report()
+		}
+	}
+	if p.progress {
+		report()
+		fmt.Fprintln(os.Stderr)
+	}
+	return emitErr
+}
+
+// formatBytes renders n with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func hashFiles(args []string, recursive bool, pool hashPool, encoding string) error {
+	paths, err := collectFiles(args, recursive)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	return pool.run(paths, func(i int, sums [][]byte, err error) error {
+		if err != nil {
+			return err
+		}
+		for j, sum := range sums {
+			digest, err := encodeDigest(sum, encoding)
+			if err != nil {
+				return err
+			}
+			if len(sums) == 1 {
+				fmt.Fprintln(w, sumLine(digest, paths[i]))
+			} else {
+				fmt.Fprintln(w, tagLine(pool.algos[j].name, digest, paths[i]))
+			}
+		}
+		return nil
+	})
+}
+
+// tagLine formats a BSD-style "SHA256 (path) = digest" line, used when several
+// algorithms are computed in one pass.
+func tagLine(algo, digest, path string) string {
+	return fmt.Sprintf("%s (%s) = %s", strings.ToUpper(algo), path, digest)
+}
+
+// sumLine formats a coreutils checksum line. Names containing a backslash or line
+// break are escaped and the line is prefixed with a backslash, as sha256sum does.
+func sumLine(digest, path string) string {
+	if !strings.ContainsAny(path, "\\\n\r") {
+		return digest + "  " + path
+	}
+	r := strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\r", "\\r")
+	return "\\" + digest + "  " + r.Replace(path)
+}
+
+// parseSumLine reads a sumLine or tagLine. name is the algorithm of a tag line and
+// empty for sha256sum-style lines, which may carry the '*' binary-mode marker.
+func parseSumLine(line string) (name, digest, path string, ok bool) {
+	if m := tagLineRe.FindStringSubmatch(line); m != nil {
+		return m[1], m[3], m[2], true
+	}
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+	digest, path, ok = strings.Cut(line, " ")
+	if !ok || digest == "" || path == "" || (path[0] != ' ' && path[0] != '*') {
+		return "", "", "", false
+	}
+	path = path[1:]
+	if escaped {
+		r := strings.NewReplacer("\\\\", "\\", "\\n", "\n", "\\r", "\r")
+		path = r.Replace(path)
+	}
+	return "", digest, path, path != ""
+}
+
+var tagLineRe = regexp.MustCompile(`^([A-Za-z0-9-]+) \((.+)\) = ([0-9A-Za-z+/_=-]+)$`)
+
+// digestDecoders covers every text form encodeDigest produces.
+var digestDecoders = []func(string) ([]byte, error){
+	hex.DecodeString,
+	base64.StdEncoding.DecodeString,
+	base64.RawURLEncoding.DecodeString,
+	base32.StdEncoding.DecodeString,
+}
+
+// decodeDigest accepts a hex, base64, base64url or base32 digest of the given size.
+func decodeDigest(s string, size int) ([]byte, bool) {
+	for _, decode := range digestDecoders {
+		if b, err := decode(s); err == nil && len(b) == size {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// checkSums verifies every file listed in sumsPath and reports like coreutils.
+// Lines may be in sha256sum format or BSD tag format; tag lines for other
+// algorithms are skipped so one multi-algorithm list serves every command.
+func checkSums(cmd *cobra.Command, sumsPath string, pool hashPool) error {
+	var r io.Reader = os.Stdin
+	if sumsPath != "-" {
+		f, err := os.Open(sumsPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	algo := pool.algos[0]
+	size := algo.factory().Size()
+	var paths []string
+	var wants [][]byte
+	malformed := 0
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, digest, path, ok := parseSumLine(line)
+		if ok && name != "" && !strings.EqualFold(name, algo.name) {
+			continue
+		}
+		want, valid := decodeDigest(digest, size)
+		if !ok || !valid {
+			malformed++
+			continue
+		}
+		paths = append(paths, path)
+		wants = append(wants, want)
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+	if len(paths) == 0 {
+		return fmt.Errorf("%s: no properly formatted %s checksum lines found", sumsPath, algo.name)
+	}
+	if sumsPath == "-" && slices.Contains(paths, "-") {
+		return errors.New(`the checksum list read from stdin names "-", which would read stdin again; save the list to a file`)
+	}
+	var failed, unreadable int
+	err := pool.run(paths, func(i int, sums [][]byte, err error) error {
+		switch {
+		case err != nil:
+			unreadable++
+			fmt.Fprintf(os.Stderr, "%s: %v\n", paths[i], err)
+			fmt.Printf("%s: FAILED open or read\n", paths[i])
+		case !bytes.Equal(sums[0], wants[i]):
+			failed++
+			fmt.Printf("%s: FAILED\n", paths[i])
+		default:
+			fmt.Printf("%s: OK\n", paths[i])
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if malformed > 0 {
+		fmt.Fprintf(os.Stderr, "WARNING: %d %s improperly formatted\n", malformed, plural(malformed, "line is", "lines are"))
+	}
+	if unreadable > 0 {
+		fmt.Fprintf(os.Stderr, "WARNING: %d listed %s could not be read\n", unreadable, plural(unreadable, "file", "files"))
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "WARNING: %d computed %s did NOT match\n", failed, plural(failed, "checksum", "checksums"))
+	}
+	if failed+unreadable > 0 {
+		return errors.New("checksum verification failed")
+	}
+	return nil
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"os"
+	"regexp"
+	"strings"
+
+	"dt/internal/cliio"
+	"dt/internal/passwdutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	hashIdentifyPlaintext     bool
+	hashIdentifyPlaintextFile string
+)
+
+func init() {
+	hashIdentifyCmd.Flags().BoolVar(&hashIdentifyPlaintext, "plaintext", false, "read a plaintext (stdin or prompt) and report which candidate it matches")
+	hashIdentifyCmd.Flags().StringVar(&hashIdentifyPlaintextFile, "plaintext-file", "", "like --plaintext, reading the plaintext from a file (one trailing newline is ignored)")
+	hashCmd.AddCommand(hashIdentifyCmd)
+}
+
+var hashIdentifyCmd = &cobra.Command{
+	Use:   "identify [hashes...]",
+	Short: "Guess which algorithm produced a hash",
+	Long: `Inspects each hash (arguments, or stdin one per line) by prefix, length and charset.
+Password hash formats such as $2b$, $argon2id$, $6$ and LDAP {SSHA} are named directly;
+bare hex, base64 and base32 digests list every dt hash algorithm of that size, plus
+well-known ones dt cannot compute. With --plaintext, each candidate dt can compute is
+tried and the match is reported; no match exits non-zero. The plaintext is read like a
+password, from stdin when piped (hashes then come from the arguments) or from a prompt
+that does not echo, so it stays out of shell history; --plaintext-file reads it from a
+file instead and leaves stdin for the hashes.`,
+	Example: `dt hash identify 5d41402abc4b2a76b9719d911017c592
+echo -n hello | dt hash identify --plaintext 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824
+psql -Atc "select password from users limit 5" | dt hash identify --plaintext-file guess.txt`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tryPlaintext := hashIdentifyPlaintext || hashIdentifyPlaintextFile != ""
+		plaintextOnStdin := tryPlaintext && hashIdentifyPlaintextFile == ""
+		var inputs []string
+		if cliio.IsInputFromPipe() && !plaintextOnStdin {
+			b, err := cliio.ReadAll(nil)
+			if err != nil {
+				return err
+			}
+			inputs = cliio.ReadLines(b)
+		} else {
+			inputs = args
+		}
+		var hashes []string
+		for _, s := range inputs {
+			if s = strings.TrimSpace(s); s != "" {
+				hashes = append(hashes, s)
+			}
+		}
+		if len(hashes) == 0 {
+			return fmt.Errorf("no input provided")
+		}
+		var plaintext []byte
+		if tryPlaintext {
+			var err error
+			if plaintext, err = readPlaintext(hashIdentifyPlaintextFile); err != nil {
+				return err
+			}
+		}
+		unknown, unmatched := 0, 0
+		for i, s := range hashes {
+			if i > 0 {
+				fmt.Println()
+			}
+			id := identifyHash(s)
+			row := func(k, v string) { fmt.Printf("%-11s %s\n", k, v) }
+			row("hash:", s)
+			row("format:", id.format)
+			if len(id.guesses) == 0 {
+				unknown++
+				row("candidates:", "none recognised")
+				continue
+			}
+			for j, g := range id.guesses {
+				label := ""
+				if j == 0 {
+					label = "candidates:"
+				}
+				if g.note != "" {
+					row(label, g.name+" ("+g.note+")")
+				} else {
+					row(label, g.name)
+				}
+			}
+			if !tryPlaintext {
+				continue
+			}
+			var matches []string
+			checked := false
+			for _, g := range id.guesses {
+				if g.check == nil {
+					continue
+				}
+				checked = true
+				ok, err := g.check(plaintext)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%s: %v\n", g.name, err)
+					continue
+				}
+				if ok {
+					matches = append(matches, g.name)
+				}
+			}
+			switch {
+			case !checked:
+				row("match:", "not checked; dt cannot compute these")
+			case len(matches) == 0:
+				unmatched++
+				row("match:", "none")
+			default:
+				row("match:", strings.Join(matches, ", "))
+			}
+		}
+		if unknown > 0 || unmatched > 0 {
+			cmd.SilenceUsage = true
+		}
+		switch {
+		case unknown > 0:
+			return fmt.Errorf("%d of %d hashes not recognised", unknown, len(hashes))
+		case unmatched > 0:
+			return fmt.Errorf("plaintext did not match %d of %d hashes", unmatched, len(hashes))
+		}
+		return nil
+	},
+}
+
+// readPlaintext returns the plaintext to try: the contents of file when one is
+// given, otherwise one line read like a password.
+func readPlaintext(file string) ([]byte, error) {
+	if file == "" {
+		return cliio.ReadPassword("Plaintext: ")
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSuffix(strings.TrimSuffix(string(b), "\n"), "\r")), nil
+}
+
+// hashGuess is one algorithm that could have produced a hash string.
+type hashGuess struct {
+	name  string
+	note  string
+	check func(plaintext []byte) (bool, error) // nil when dt cannot recompute the hash
+}
+
+type hashIdentity struct {
+	format  string
+	guesses []hashGuess
+}
+
+// cryptFormats are password hash prefixes dt recognises but cannot verify.
+var cryptFormats = []struct{ prefix, name, note string }{
+	{"$1$", "md5crypt", "MD5-based crypt(3)"},
+	{"$apr1$", "apr1", "Apache htpasswd MD5"},
+	{"$5$", "sha256crypt", "SHA-256 crypt(3)"},
+	{"$6$", "sha512crypt", "SHA-512 crypt(3), common in /etc/shadow"},
+	{"$y$", "yescrypt", "crypt(3) default on recent Linux distributions"},
+	{"$7$", "scrypt", "crypt(3) $7$ encoding"},
+	{"$argon2d$", "argon2d", "PHC string"},
+	{"$P$", "phpass", "WordPress portable hash"},
+	{"$H$", "phpass", "phpBB portable hash"},
+	{"$sha1$", "sha1crypt", "NetBSD SHA-1 crypt"},
+	{"pbkdf2_sha256$", "django pbkdf2_sha256", "Django password field"},
+	{"pbkdf2_sha1$", "django pbkdf2_sha1", "Django password field"},
+	{"argon2$", "django argon2", "Django password field"},
+	{"bcrypt_sha256$", "django bcrypt_sha256", "Django password field"},
+	{"scrypt$", "django scrypt", "Django password field"},
+}
+
+// ldapSchemes maps RFC 2307 password schemes to their digest; "S" variants append
+// the salt to the digest inside the base64 value.
+var ldapSchemes = map[string]struct {
+	factory func() hash.Hash
+	salted  bool
+}{
+	"MD5":     {md5.New, false},
+	"SMD5":    {md5.New, true},
+	"SHA":     {sha1.New, false},
+	"SSHA":    {sha1.New, true},
+	"SHA256":  {sha256.New, false},
+	"SSHA256": {sha256.New, true},
+	"SHA512":  {sha512.New, false},
+	"SSHA512": {sha512.New, true},
+}
+
+// otherDigests are common digests by size in bytes that dt does not compute.
+var otherDigests = map[int][]string{
+	16: {"md4", "ntlm"},
+	20: {"ripemd160"},
+	28: {"sha3-224", "sha512/224"},
+	32: {"keccak-256", "sha512/256"},
+	48: {"sha3-384"},
+	64: {"keccak-512", "whirlpool"},
+}
+
+var (
+	ldapRe    = regexp.MustCompile(`^\{([A-Za-z0-9-]+)\}(.*)$`)
+	mysql41Re = regexp.MustCompile(`^\*[0-9A-Fa-f]{40}$`)
+)
+
+// identifyHash lists the algorithms that could have produced s, most likely first.
+func identifyHash(s string) hashIdentity {
+	if m := ldapRe.FindStringSubmatch(s); m != nil {
+		return identifyLDAP(strings.ToUpper(m[1]), m[2])
+	}
+	if mysql41Re.MatchString(s) {
+		want, _ := hex.DecodeString(s[1:])
+		return hashIdentity{format: "MySQL PASSWORD()", guesses: []hashGuess{{
+			name: "mysql41",
+			note: "sha1(sha1(password)), MySQL 4.1+",
+			check: func(pt []byte) (bool, error) {
+				inner := sha1.Sum(pt)
+				outer := sha1.Sum(inner[:])
+				return bytes.Equal(outer[:], want), nil
+			},
+		}}}
+	}
+	if strings.HasPrefix(s, "$2") || strings.HasPrefix(s, "$argon2i") || strings.HasPrefix(s, "$scrypt$") || strings.HasPrefix(s, "$pbkdf2-") {
+		h, err := passwdutil.Parse(s)
+		if err != nil {
+			return hashIdentity{format: "modular crypt (malformed: " + err.Error() + ")"}
+		}
+		params := make([]string, 0, len(h.Params))
+		for _, f := range h.Params {
+			params = append(params, f.Name+" "+f.Value)
+		}
+		return hashIdentity{format: "modular crypt", guesses: []hashGuess{{
+			name:  h.Algorithm,
+			note:  strings.Join(params, ", "),
+			check: func(pt []byte) (bool, error) { return h.Verify(pt) },
+		}}}
+	}
+	for _, f := range cryptFormats {
+		if strings.HasPrefix(s, f.prefix) {
+			return hashIdentity{format: "modular crypt", guesses: []hashGuess{{name: f.name, note: f.note + "; not verifiable by dt"}}}
+		}
+	}
+	return identifyDigest(s)
+}
+
+func identifyLDAP(scheme, value string) hashIdentity {
+	if scheme == "CRYPT" {
+		inner := identifyHash(value)
+		inner.format = "LDAP {CRYPT}, " + inner.format
+		return inner
+	}
+	format := "LDAP {" + scheme + "}"
+	ls, ok := ldapSchemes[scheme]
+	if !ok {
+		return hashIdentity{format: format + ", unknown scheme"}
+	}
+	raw, err := base64.StdEncoding.DecodeString(value)
+	size := ls.factory().Size()
+	if err != nil || len(raw) < size || (!ls.salted && len(raw) != size) {
+		return hashIdentity{format: format + " (malformed base64 value)"}
+	}
+	digest, salt := raw[:size], raw[size:]
+	name, note := strings.ToLower(scheme), "unsalted"
+	if ls.salted {
+		name = name[1:]
+		note = fmt.Sprintf("salted, %d-byte salt after the password", len(salt))
+	}
+	if name == "sha" {
+		name = "sha1"
+	}
+	return hashIdentity{format: format, guesses: []hashGuess{{
+		name: "ldap-" + strings.ToLower(scheme),
+		note: name + ", " + note,
+		check: func(pt []byte) (bool, error) {
+			h := ls.factory()
+			h.Write(pt)
+			h.Write(salt)
+			return bytes.Equal(h.Sum(nil), digest), nil
+		},
+	}}}
+}
+
+// digestForms are the encodings tried for bare digests, in order of preference:
+// a string of hex digits is read as hex even if it is also valid base64.
+var digestForms = []struct {
+	name   string
+	decode func(string) ([]byte, error)
+}{
+	{"hex", hex.DecodeString},
+	{"base64", base64.StdEncoding.DecodeString},
+	{"base64url", base64.RawURLEncoding.DecodeString},
+	{"base32", base32.StdEncoding.DecodeString},
+}
+
+func identifyDigest(s string) hashIdentity {
+	var first *hashIdentity
+	for _, form := range digestForms {
+		raw, err := form.decode(s)
+		if err != nil || len(raw) == 0 {
+			continue
+		}
+		id := hashIdentity{format: fmt.Sprintf("%s, %d bytes", form.name, len(raw))}
+		for _, a := range hashAlgorithms {
+			if a.factory().Size() != len(raw) {
+				continue
+			}
+			g := hashGuess{name: a.name, check: digestCheck(a.factory, raw)}
+			if a.checksum {
+				g.note = "checksum"
+				if a.seeded != nil {
+					g.note = "checksum, checked with seed 0"
+				}
+			}
+			id.guesses = append(id.guesses, g)
+		}
+		if others := otherDigests[len(raw)]; len(others) > 0 {
+			id.guesses = append(id.guesses, hashGuess{name: strings.Join(others, ", "), note: "not computed by dt"})
+		}
+		if len(id.guesses) > 0 {
+			return id
+		}
+		if first == nil {
+			first = &id
+		}
+	}
+	if first != nil {
+		return *first
+	}
+	return hashIdentity{format: "unknown encoding"}
+}
+
+func digestCheck(factory func() hash.Hash, want []byte) func([]byte) (bool, error) {
+	return func(pt []byte) (bool, error) {
+		h := factory()
+		h.Write(pt)
+		return bytes.Equal(h.Sum(nil), want), nil
+	}
+}
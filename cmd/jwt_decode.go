@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"dt/internal/cliio"
+	"dt/internal/dateutil"
+	"dt/internal/jsonutil"
+	"dt/internal/jwtutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	jwtDecodeFormat string
+	jwtDecodeLayout string
+	jwtDecodeUTC    bool
+)
+
+var jwtCmd = &cobra.Command{
+	Use:   "jwt",
+	Short: "Decode, sign and verify JSON Web Tokens",
+}
+
+func init() {
+	jwtCmd.AddCommand(jwtDecodeCmd)
+	rootCmd.AddCommand(jwtCmd)
+
+	jwtDecodeCmd.Flags().StringVar(&jwtDecodeFormat, "format", "rfc3339", "time claim format: rfc3339|unix|layout|<preset>|<Go layout or strftime>")
+	jwtDecodeCmd.Flags().StringVar(&jwtDecodeLayout, "layout", "", "Go layout, strftime pattern or preset when --format=layout")
+	jwtDecodeCmd.Flags().BoolVar(&jwtDecodeUTC, "utc", false, "print time claims in UTC")
+	jwtDecodeCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return dateutil.FormatNames(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+var jwtDecodeCmd = &cobra.Command{
+	Use:   "decode [token]",
+	Short: "Pretty-print a JWT's header and payload without verifying it",
+	Long: `Splits a JWT (argument or stdin; a "Bearer " prefix is ignored), pretty-prints the
+header and payload, and renders the exp, nbf and iat claims as times with their status
+relative to now. The signature is shown but NOT checked; use "dt jwt verify" for that.`,
+	Example: `dt jwt decode eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxMjMifQ.c2ln
+pbpaste | dt jwt decode --utc`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		raw, err := readToken(args)
+		if err != nil {
+			return err
+		}
+		t, err := jwtutil.Parse(raw)
+		if err != nil {
+			return err
+		}
+		header, err := jsonutil.Pretty(t.Header, 2)
+		if err != nil {
+			return fmt.Errorf("header: %w", err)
+		}
+		fmt.Println("header:")
+		fmt.Println(string(header))
+		fmt.Println("payload:")
+		if payload, err := jsonutil.Pretty(t.Payload, 2); err == nil {
+			fmt.Println(string(payload))
+		} else {
+			fmt.Println(string(t.Payload)) // JWS payloads need not be JSON
+		}
+		for _, c := range jwtTimeClaims(t.Payload) {
+			if c.invalid != "" {
+				fmt.Printf("%-10s %s (not a NumericDate)\n", c.name+":", c.invalid)
+				continue
+			}
+			fmt.Printf("%-10s %s (%s)\n", c.name+":", dateutil.FormatTime(c.at, jwtDecodeFormat, jwtDecodeLayout, jwtDecodeUTC), c.status)
+		}
+		fmt.Printf("%-10s %d bytes, %s, not verified\n", "signature:", len(t.Signature), t.Alg)
+		return nil
+	},
+}
+
+// readToken reads a token from the single argument or stdin.
+func readToken(args []string) (string, error) {
+	if len(args) == 1 {
+		return args[0], nil
+	}
+	b, err := cliio.ReadAll(nil)
+	if err != nil {
+		return "", err
+	}
+	s := strings.TrimSpace(string(b))
+	if s == "" {
+		return "", fmt.Errorf("no token provided")
+	}
+	return s, nil
+}
+
+// jwtTimeClaim is a registered NumericDate claim with its status relative to now.
+type jwtTimeClaim struct {
+	name    string
+	at      time.Time
+	status  string
+	invalid string // the JSON value, when it is not a NumericDate
+}
+
+// jwtTimeClaims extracts exp, nbf and iat from payload, skipping absent ones.
+// A present value that is not a NumericDate is returned with invalid set.
+func jwtTimeClaims(payload []byte) []jwtTimeClaim {
+	var claims map[string]any
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	if dec.Decode(&claims) != nil {
+		return nil
+	}
+	current := now()
+	var out []jwtTimeClaim
+	for _, name := range []string{"exp", "nbf", "iat"} {
+		v, ok := claims[name]
+		if !ok {
+			continue
+		}
+		at, err := numericDate(v)
+		if err != nil {
+			raw, _ := json.Marshal(v)
+			out = append(out, jwtTimeClaim{name: name, invalid: string(raw)})
+			continue
+		}
+		d := at.Sub(current)
+		var status string
+		switch name {
+		case "exp":
+			if d <= 0 {
+				status = fmt.Sprintf("expired %s ago", roughDuration(-d))
+			} else {
+				status = fmt.Sprintf("expires in %s", roughDuration(d))
+			}
+		case "nbf":
+			if d > 0 {
+				status = fmt.Sprintf("not valid for another %s", roughDuration(d))
+			} else {
+				status = "active"
+			}
+		case "iat":
+			if d > 0 {
+				status = fmt.Sprintf("issued %s in the future", roughDuration(d))
+			} else {
+				status = fmt.Sprintf("issued %s ago", roughDuration(-d))
+			}
+		}
+		out = append(out, jwtTimeClaim{name: name, at: at, status: status})
+	}
+	return out
+}
+
+// maxNumericDate is the last second of year 9999, beyond which dates cannot be
+// formatted.
+const maxNumericDate = 253402300799
+
+// numericDate converts a decoded JSON value to a time following RFC 7519: a
+// number of seconds since the epoch, which may use a fraction or an exponent.
+func numericDate(v any) (time.Time, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, fmt.Errorf("not a number")
+	}
+	f, err := n.Float64()
+	if err != nil || math.Abs(f) > maxNumericDate {
+		return time.Time{}, fmt.Errorf("out of range")
+	}
+	sec := math.Floor(f)
+	return time.Unix(int64(sec), int64(math.Round((f-sec)*1e9))), nil
+}
+
+// roughDuration rounds d to seconds, and to whole hours once it spans days.
+func roughDuration(d time.Duration) string {
+	if d < 48*time.Hour {
+		return d.Round(time.Second).String()
+	}
+	d = d.Round(time.Hour)
+	return fmt.Sprintf("%dd%dh", d/(24*time.Hour), d%(24*time.Hour)/time.Hour)
+}
+
+// jwtKeys loads the keys named by --secret, --secret-file or --key.
+func jwtKeys(secret, secretFile, keyFile string) ([]jwtutil.Key, error) {
+	set := 0
+	for _, v := range []string{secret, secretFile, keyFile} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("give exactly one of --secret, --secret-file or --key")
+	}
+	switch {
+	case secret != "":
+		return []jwtutil.Key{{Key: []byte(secret)}}, nil
+	case secretFile != "":
+		k, err := hmacKey("", secretFile)
+		if err != nil {
+			return nil, err
+		}
+		return []jwtutil.Key{{Key: k}}, nil
+	default:
+		b, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := jwtutil.LoadKeys(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", keyFile, err)
+		}
+		return keys, nil
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dt/internal/dateutil"
+	"dt/internal/jwtutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	jwtVerifyAlg        string
+	jwtVerifySecret     string
+	jwtVerifySecretFile string
+	jwtVerifyKey        string
+	jwtVerifyLeeway     time.Duration
+)
+
+func init() {
+	jwtCmd.AddCommand(jwtVerifyCmd)
+
+	jwtVerifyCmd.Flags().StringVar(&jwtVerifyAlg, "alg", "", "require this algorithm instead of trusting the header")
+	jwtVerifyCmd.Flags().StringVar(&jwtVerifySecret, "secret", "", "HMAC secret for HS*")
+	jwtVerifyCmd.Flags().StringVar(&jwtVerifySecretFile, "secret-file", "", "read the HMAC secret from a file (one trailing newline is ignored)")
+	jwtVerifyCmd.Flags().StringVar(&jwtVerifyKey, "key", "", "public or private key file: PEM, certificate, JWK or JWKS (selected by kid)")
+	jwtVerifyCmd.Flags().DurationVar(&jwtVerifyLeeway, "leeway", 0, "tolerated clock skew for exp and nbf, e.g. 30s")
+	jwtVerifyCmd.RegisterFlagCompletionFunc("alg", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return jwtutil.Algorithms, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+var jwtVerifyCmd = &cobra.Command{
+	Use:   "verify [token]",
+	Short: "Check a JWT's signature and exp/nbf claims",
+	Long: `Verifies the signature of a JWT (argument or stdin) with an HMAC secret or a local key,
+then checks exp and nbf against the current time. With a JWKS, the key is chosen by the
+token's kid, or every compatible key is tried when there is none. Unsigned tokens
+(alg "none") are always rejected. Exits non-zero if the token is not valid.`,
+	Example: `dt jwt verify --secret s3cret "$TOKEN"
+dt jwt verify --key jwks.json --alg RS256 < token.txt`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		raw, err := readToken(args)
+		if err != nil {
+			return err
+		}
+		t, err := jwtutil.Parse(raw)
+		if err != nil {
+			return err
+		}
+		keys, err := jwtKeys(jwtVerifySecret, jwtVerifySecretFile, jwtVerifyKey)
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true // from here on, failures are results, not usage errors
+		if jwtVerifyAlg != "" && t.Alg != jwtVerifyAlg {
+			return fmt.Errorf("token uses %s, expected %s", t.Alg, jwtVerifyAlg)
+		}
+		if strings.EqualFold(t.Alg, "none") || t.Alg == "" {
+			return errors.New(`unsigned token (alg "none") rejected`)
+		}
+		candidates := jwtutil.Candidates(keys, t.Alg, t.Kid)
+		if len(candidates) == 0 {
+			return fmt.Errorf("no key suitable for %s (have %s)", t.Alg, jwtutil.KeyType(keys[0].Key))
+		}
+		var used *jwtutil.Key
+		for i, k := range candidates {
+			if err = t.Verify(k.Key); err == nil {
+				used = &candidates[i]
+				break
+			}
+		}
+		if used == nil {
+			return err
+		}
+		sig := t.Alg
+		if used.Kid != "" {
+			sig += ", kid " + used.Kid
+		}
+		fmt.Printf("%-10s valid (%s)\n", "signature:", sig)
+		current := now()
+		var problems []string
+		for _, c := range jwtTimeClaims(t.Payload) {
+			if c.invalid != "" {
+				fmt.Printf("%-10s %s (not a NumericDate)\n", c.name+":", c.invalid)
+				problems = append(problems, fmt.Sprintf("invalid %s claim", c.name))
+				continue
+			}
+			fmt.Printf("%-10s %s (%s)\n", c.name+":", dateutil.FormatTime(c.at, "rfc3339", "", false), c.status)
+			switch {
+			case c.name == "exp" && !current.Before(c.at.Add(jwtVerifyLeeway)):
+				problems = append(problems, "token expired")
+			case c.name == "nbf" && current.Add(jwtVerifyLeeway).Before(c.at):
+				problems = append(problems, "token not yet valid")
+			}
+		}
+		if len(problems) > 0 {
+			return errors.New(strings.Join(problems, "; "))
+		}
+		return nil
+	},
+}
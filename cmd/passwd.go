@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"dt/internal/cliio"
+	"dt/internal/passwdutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	passwdAlgo       string
+	passwdCost       int
+	passwdTime       uint32
+	passwdMemory     uint32
+	passwdThreads    uint8
+	passwdLogN       int
+	passwdBlockSize  int
+	passwdParallel   int
+	passwdIterations int
+	passwdDigest     string
+	passwdSaltLen    int
+	passwdKeyLen     int
+)
+
+var passwdCmd = &cobra.Command{
+	Use:   "passwd",
+	Short: "Hash and verify passwords (bcrypt, argon2id, scrypt, pbkdf2)",
+	Long: `Produces and checks the password hashes applications store. Passwords are read from
+stdin when piped (first line, without its line ending) or from a prompt that does not
+echo, never from arguments, so they stay out of shell history.`,
+}
+
+func init() {
+	d := passwdutil.DefaultOptions()
+	passwdHashCmd.Flags().StringVar(&passwdAlgo, "algo", "bcrypt", "algorithm: "+strings.Join(passwdutil.Algorithms, "|"))
+	passwdHashCmd.Flags().IntVar(&passwdCost, "cost", d.Cost, "bcrypt cost (log2 rounds, 4-31)")
+	passwdHashCmd.Flags().Uint32Var(&passwdTime, "time", d.Time, "argon2id passes over memory")
+	passwdHashCmd.Flags().Uint32Var(&passwdMemory, "memory", d.Memory, "argon2id memory in KiB")
+	passwdHashCmd.Flags().Uint8Var(&passwdThreads, "threads", d.Threads, "argon2id parallelism")
+	passwdHashCmd.Flags().IntVar(&passwdLogN, "ln", d.LogN, "scrypt log2 of N")
+	passwdHashCmd.Flags().IntVar(&passwdBlockSize, "block-size", d.R, "scrypt block size r")
+	passwdHashCmd.Flags().IntVar(&passwdParallel, "parallelism", d.P, "scrypt parallelism p")
+	passwdHashCmd.Flags().IntVar(&passwdIterations, "iterations", d.Iterations, "pbkdf2 iterations")
+	passwdHashCmd.Flags().StringVar(&passwdDigest, "digest", d.Digest, "pbkdf2 digest: "+strings.Join(passwdutil.Digests, "|"))
+	passwdHashCmd.Flags().IntVar(&passwdSaltLen, "salt-len", d.SaltLen, "salt length in bytes (bcrypt always uses 16)")
+	passwdHashCmd.Flags().IntVar(&passwdKeyLen, "key-len", d.KeyLen, "derived key length in bytes (not bcrypt)")
+	passwdHashCmd.RegisterFlagCompletionFunc("algo", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return passwdutil.Algorithms, cobra.ShellCompDirectiveNoFileComp
+	})
+	passwdHashCmd.RegisterFlagCompletionFunc("digest", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return passwdutil.Digests, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	passwdCmd.AddCommand(passwdHashCmd)
+	passwdCmd.AddCommand(passwdVerifyCmd)
+	rootCmd.AddCommand(passwdCmd)
+}
+
+var passwdHashCmd = &cobra.Command{
+	Use:   "hash",
+	Short: "Hash a password",
+	Long: `Hashes a password with a fresh random salt. bcrypt output uses the $2b$ modular crypt
+format; argon2id, scrypt and pbkdf2 use PHC strings ($argon2id$v=19$m=...,t=...,p=...$salt$hash).
+Defaults follow the OWASP password storage recommendations.`,
+	Example: `dt passwd hash
+echo -n 'correct horse' | dt passwd hash --algo argon2id --memory 65536 --time 3
+echo -n 'hunter2' | dt passwd hash --algo pbkdf2 --digest sha512 --iterations 210000`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pw, err := readNewPassword()
+		if err != nil {
+			return err
+		}
+		opts := passwdutil.Options{
+			Cost:       passwdCost,
+			Time:       passwdTime,
+			Memory:     passwdMemory,
+			Threads:    passwdThreads,
+			LogN:       passwdLogN,
+			R:          passwdBlockSize,
+			P:          passwdParallel,
+			Iterations: passwdIterations,
+			Digest:     passwdDigest,
+			SaltLen:    passwdSaltLen,
+			KeyLen:     passwdKeyLen,
+		}
+		out, err := passwdutil.Hash(secretRNG(), passwdAlgo, pw, opts)
+		if err != nil {
+			return err
+		}
+		fmt.Println(out)
+		return nil
+	},
+}
+
+// readNewPassword reads the password to hash, asking twice when prompting.
+func readNewPassword() ([]byte, error) {
+	pw, err := cliio.ReadPassword("Password: ")
+	if err != nil || cliio.IsInputFromPipe() {
+		return pw, err
+	}
+	again, err := cliio.ReadPassword("Confirm password: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pw, again) {
+		return nil, errors.New("passwords do not match")
+	}
+	return pw, nil
+}
+
+var passwdVerifyCmd = &cobra.Command{
+	Use:   "verify <hash>",
+	Short: "Check a password against a stored hash",
+	Long: `Parses a bcrypt ($2a$/$2b$/$2y$) or PHC (argon2id, argon2i, scrypt, pbkdf2-sha1/sha256/sha512)
+hash, reports its decoded parameters and whether the password matches. Exits non-zero on a
+mismatch. Quote the hash: it contains $ signs.`,
+	Example: `dt passwd verify '$2b$12$...'
+echo -n 'hunter2' | dt passwd verify "$(psql -Atc "select password_hash from users where id = 1")"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		h, err := passwdutil.Parse(args[0])
+		if err != nil {
+			return err
+		}
+		pw, err := cliio.ReadPassword("Password: ")
+		if err != nil {
+			return err
+		}
+		ok, err := h.Verify(pw)
+		if err != nil {
+			return err
+		}
+		row := func(k, v string) { fmt.Printf("%-11s %s\n", k+":", v) }
+		match := "yes"
+		if !ok {
+			match = "no"
+		}
+		row("match", match)
+		row("algorithm", h.Algorithm)
+		for _, f := range h.Params {
+			row(f.Name, f.Value)
+		}
+		row("salt", fmt.Sprintf("%d bytes", len(h.Salt)))
+		row("hash", fmt.Sprintf("%d bytes", len(h.Key)))
+		if !ok {
+			cmd.SilenceUsage = true // a mismatch is a result, not a usage error
+			return errors.New("password does not match")
+		}
+		return nil
+	},
+}
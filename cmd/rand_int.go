@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+
+	"dt/internal/randutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	randIntMin   int64
+	randIntMax   int64
+	randIntCount int
+)
+
+func init() {
+	randCmd.AddCommand(randIntCmd)
+
+	randIntCmd.Flags().Int64Var(&randIntMin, "min", 0, "smallest value (inclusive)")
+	randIntCmd.Flags().Int64Var(&randIntMax, "max", 100, "largest value (inclusive)")
+	randIntCmd.Flags().IntVarP(&randIntCount, "count", "n", 1, "number of integers to generate")
+}
+
+var randIntCmd = &cobra.Command{
+	Use:     "int",
+	Short:   "Generate uniform random integers in [--min, --max]",
+	Example: `dt rand int --min 1 --max 6 -n 3`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for i := 0; i < max(randIntCount, 1); i++ {
+			v, err := randutil.IntRange(rng, randIntMin, randIntMax)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+		}
+		printEntropy(math.Log2(float64(randIntMax) - float64(randIntMin) + 1))
+		return nil
+	},
+}
@@ -1,16 +1,66 @@
 package cmd
 
 import (
+    "crypto/rand"
+    "crypto/sha256"
     "fmt"
+    "io"
+    mrand "math/rand/v2"
     "os"
+    "time"
 
+    "dt/internal/dateutil"
     "github.com/spf13/cobra"
 )
 
+var (
+    globalSeed         string
+    globalInsecureSeed bool
+    globalNow          string
+
+    // rng, seeded and fixedNow are resolved from --seed/DT_SEED and --now before each command runs.
+    rng      io.Reader = rand.Reader
+    seeded   bool
+    fixedNow time.Time
+)
+
 var rootCmd = &cobra.Command{
     Use:   "dt",
     Short: "dt: day-to-day developer toolbox",
     Long:  "dt is a small, focused CLI to speed up daily dev tasks (JSON, dates, base64, UUIDs, env conversions).",
+    PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+        seed := globalSeed
+        if seed == "" {
+            seed = os.Getenv("DT_SEED")
+        }
+        rng, seeded = rand.Reader, seed != ""
+        if seeded {
+            rng = mrand.NewChaCha8(sha256.Sum256([]byte(seed)))
+        }
+        fixedNow = time.Time{}
+        if globalNow != "" {
+            t, err := dateutil.ParseFlexible(globalNow, "", false)
+            if err != nil {
+                return fmt.Errorf("invalid --now: %w", err)
+            }
+            fixedNow = t
+        }
+        return nil
+    },
+}
+
+func init() {
+    rootCmd.PersistentFlags().StringVar(&globalSeed, "seed", "", "make generators deterministic from this seed (env DT_SEED)")
+    rootCmd.PersistentFlags().BoolVar(&globalInsecureSeed, "insecure-seed", false, "apply --seed to secrets too (random bytes, passwords, salts); for test fixtures only")
+    rootCmd.PersistentFlags().StringVar(&globalNow, "now", "", "use this time instead of the system clock")
+}
+
+// now returns the --now override or the current time.
+func now() time.Time {
+    if !fixedNow.IsZero() {
+        return fixedNow
+    }
+    return time.Now()
 }
 
 // Execute is the program entry from main.
@@ -20,4 +70,3 @@ func Execute() {
         os.Exit(1)
     }
 }
-
@@ -1,13 +1,19 @@
 package cmd
 
 import (
-    "crypto/rand"
     "fmt"
 
+    "dt/internal/cliio"
+    "dt/internal/uuidutil"
     "github.com/spf13/cobra"
 )
 
-var uuidCount int
+var (
+    uuidCount     int
+    uuidVersion   int
+    uuidNamespace string
+    uuidName      string
+)
 
 func init() {
     rootCmd.AddCommand(uuidCmd)
@@ -18,26 +24,74 @@ var uuidCmd = &cobra.Command{Use: "uuid", Short: "UUID utilities"}
 
 var uuidNewCmd = &cobra.Command{
     Use:   "new",
-    Short: "Generate UUIDv4",
+    Short: "Generate UUIDs (v4 by default; v1, v3, v5, v6, v7 via --version)",
+    Long: `Generates UUIDs per RFC 9562. v4 is random, v7 is Unix-millisecond time-ordered (good
+database keys), v1/v6 are Gregorian time-based with a random node, and v3/v5 are
+name-based: they hash --name (or each piped line) within --namespace.`,
+    Example: `dt uuid new -n 3
+dt uuid new --version 7
+dt uuid new --version 5 --namespace dns --name example.com`,
     RunE: func(cmd *cobra.Command, args []string) error {
         if uuidCount <= 0 {
             uuidCount = 1
         }
+        switch uuidVersion {
+        case 3, 5:
+            if cmd.Flags().Changed("count") {
+                return fmt.Errorf("--count does not apply to version %d; it prints one UUID per name", uuidVersion)
+            }
+            ns, err := uuidutil.ParseNamespace(uuidNamespace)
+            if err != nil {
+                return err
+            }
+            names := []string{uuidName}
+            if uuidName == "" {
+                if !cliio.IsInputFromPipe() {
+                    return fmt.Errorf("--name is required for version %d", uuidVersion)
+                }
+                b, err := cliio.ReadAll(nil)
+                if err != nil {
+                    return err
+                }
+                names = cliio.ReadLines(b)
+            }
+            for _, name := range names {
+                if uuidVersion == 3 {
+                    fmt.Println(uuidutil.NewV3(ns, name))
+                } else {
+                    fmt.Println(uuidutil.NewV5(ns, name))
+                }
+            }
+            return nil
+        case 1, 4, 6, 7:
+        default:
+            return fmt.Errorf("unsupported version %d (use 1, 3, 4, 5, 6 or 7)", uuidVersion)
+        }
+        if uuidName != "" {
+            return fmt.Errorf("--name only applies to versions 3 and 5")
+        }
+        gen := uuidutil.NewGenerator()
+        gen.Rand, gen.Now = rng, now
         for i := 0; i < uuidCount; i++ {
-            b := make([]byte, 16)
-            if _, err := rand.Read(b); err != nil {
+            u, err := gen.New(uuidVersion)
+            if err != nil {
                 return err
             }
-            // Set version (4) and variant (RFC 4122)
-            b[6] = (b[6] & 0x0f) | 0x40
-            b[8] = (b[8] & 0x3f) | 0x80
-            fmt.Printf("%x-%x-%x-%x-%x\n", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+            fmt.Println(u)
         }
         return nil
     },
 }
 
 func init() {
-    uuidNewCmd.Flags().IntVarP(&uuidCount, "count", "n", 1, "number of UUIDs to generate")
+    uuidNewCmd.Flags().IntVarP(&uuidCount, "count", "n", 1, "number of UUIDs to generate (not for v3/v5)")
+    uuidNewCmd.Flags().IntVar(&uuidVersion, "version", 4, "UUID version: 1|3|4|5|6|7")
+    uuidNewCmd.Flags().StringVar(&uuidNamespace, "namespace", "dns", "v3/v5 namespace: dns|url|oid|x500|<uuid>")
+    uuidNewCmd.Flags().StringVar(&uuidName, "name", "", "v3/v5 name to hash (default: each line of stdin)")
+    uuidNewCmd.RegisterFlagCompletionFunc("version", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+        return []string{"4\trandom", "7\ttime-ordered", "5\tname-based SHA-1", "3\tname-based MD5", "6\treordered v1", "1\tGregorian time"}, cobra.ShellCompDirectiveNoFileComp
+    })
+    uuidNewCmd.RegisterFlagCompletionFunc("namespace", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+        return []string{"dns", "url", "oid", "x500"}, cobra.ShellCompDirectiveNoFileComp
+    })
 }
-
@@ -3,12 +3,17 @@ module dt
 go 1.24.0
 
 require (
+	github.com/cespare/xxhash/v2 v2.3.0
+	github.com/spaolacci/murmur3 v1.1.0
 	github.com/spf13/cobra v1.10.1
+	github.com/spf13/pflag v1.0.10
 	golang.org/x/crypto v0.42.0
+	golang.org/x/term v0.35.0
+	lukechampine.com/blake3 v1.4.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.10 // indirect
+	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
 	golang.org/x/sys v0.36.0 // indirect
 )
@@ -18,15 +18,33 @@ func IsInputFromPipe() bool {
     return (fi.Mode() & os.ModeCharDevice) == 0
 }
 
+// IsOutputTTY reports whether stdout is a terminal.
+func IsOutputTTY() bool {
+    fi, err := os.Stdout.Stat()
+    if err != nil {
+        return false
+    }
+    return (fi.Mode() & os.ModeCharDevice) != 0
+}
+
 // ReadAll reads from stdin if piped, otherwise joins args with spaces.
 func ReadAll(args []string) ([]byte, error) {
+    r, err := Reader(args)
+    if err != nil {
+        return nil, err
+    }
+    return io.ReadAll(r)
+}
+
+// Reader is the streaming form of ReadAll: stdin if piped, otherwise args joined with spaces.
+func Reader(args []string) (io.Reader, error) {
     if IsInputFromPipe() {
-        return io.ReadAll(bufio.NewReader(os.Stdin))
+        return bufio.NewReader(os.Stdin), nil
     }
     if len(args) == 0 {
         return nil, errors.New("no input provided; pass arguments or pipe data")
     }
-    return []byte(strings.Join(args, " ")), nil
+    return strings.NewReader(strings.Join(args, " ")), nil
 }
 
 // ReadLines splits input into lines, trimming trailing CRLF.
@@ -0,0 +1,39 @@
+package cliio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ReadPassword reads one secret line: from stdin when it is piped, otherwise from the
+// terminal with echo turned off after writing prompt to stderr. The line ending is
+// dropped; everything else, including surrounding spaces, is part of the secret.
+func ReadPassword(prompt string) ([]byte, error) {
+	if IsInputFromPipe() {
+		return readSecretLine(os.Stdin)
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, errors.New("stdin is not a terminal; pipe the password instead")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	defer fmt.Fprintln(os.Stderr)
+	return term.ReadPassword(fd)
+}
+
+func readSecretLine(r io.Reader) ([]byte, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return nil, errors.New("no password provided")
+		}
+		return nil, err
+	}
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
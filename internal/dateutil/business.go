@@ -0,0 +1,346 @@
+package dateutil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar decides which days count as business days.
+type Calendar struct {
+	Weekend  map[time.Weekday]bool
+	Holidays map[string]bool // keyed by YYYY-MM-DD
+}
+
+// NewCalendar returns a calendar with a Saturday/Sunday weekend and no holidays.
+func NewCalendar() Calendar {
+	return Calendar{
+		Weekend:  map[time.Weekday]bool{time.Saturday: true, time.Sunday: true},
+		Holidays: map[string]bool{},
+	}
+}
+
+// IsBusinessDay reports whether t's calendar date is neither a weekend day nor a holiday.
+func (c Calendar) IsBusinessDay(t time.Time) bool {
+	return !c.Weekend[t.Weekday()] && !c.Holidays[t.Format("2006-01-02")]
+}
+
+// AddBusinessDays moves t by n business days (backwards when n < 0), keeping the
+// time of day. Starting on a non-business day, +1 lands on the next business day.
+func (c Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if c.IsBusinessDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// BusinessDaysBetween counts business days after a's date up to and including b's
+// date; the result is negative when b is before a.
+func (c Calendar) BusinessDaysBetween(a, b time.Time) int {
+	sign := 1
+	if b.Before(a) {
+		a, b, sign = b, a, -1
+	}
+	start := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, a.Location())
+	end := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, a.Location())
+	n := 0
+	for d := start.AddDate(0, 0, 1); !d.After(end); d = d.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(d) {
+			n++
+		}
+	}
+	return sign * n
+}
+
+// LoadHolidays reads holiday dates from an iCalendar file (.ics), a JSON array of
+// "YYYY-MM-DD" strings or {"date": ...} objects, or a plain/YAML list with one
+// date per line ("2025-12-25", "- 2025-12-25 # Christmas", "- date: 2025-12-25",
+// "- 2025-12-25: Christmas").
+func LoadHolidays(path string) (map[string]bool, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := bytes.TrimSpace(b)
+	switch {
+	case strings.EqualFold(filepath.Ext(path), ".ics") || bytes.HasPrefix(trimmed, []byte("BEGIN:VCALENDAR")):
+		return parseICSHolidays(b)
+	case strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(trimmed, []byte("[")):
+		return parseJSONHolidays(b)
+	default:
+		return parseListHolidays(b)
+	}
+}
+
+// icsRecurYears bounds the expansion of a yearly RRULE without COUNT or UNTIL.
+const icsRecurYears = 200
+
+// icsEvent is the part of a VEVENT that decides which days it covers.
+type icsEvent struct {
+	start, end     time.Time
+	endTimed       bool // DTEND has a time after midnight, so its own date is covered
+	duration, rule string
+	extra, except  []time.Time // RDATE and EXDATE
+}
+
+// parseICSHolidays collects the days covered by each VEVENT: DTSTART up to DTEND
+// (exclusive, as for all-day events) or DURATION, repeated by a yearly RRULE and
+// RDATE and minus EXDATE. Rules it cannot expand are errors rather than guesses.
+func parseICSHolidays(b []byte) (map[string]bool, error) {
+	out := map[string]bool{}
+	var ev *icsEvent
+	for _, line := range unfoldICS(b) {
+		name, value, _ := strings.Cut(line, ":")
+		name, _, _ = strings.Cut(strings.ToUpper(name), ";")
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			ev = &icsEvent{}
+		case ev == nil:
+			// properties outside events, e.g. VTIMEZONE rules, are not holidays
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if err := ev.addDays(out); err != nil {
+				return nil, err
+			}
+			ev = nil
+		case name == "DTSTART" || name == "DTEND":
+			d, timed, err := icsDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s line: %q", name, line)
+			}
+			if name == "DTSTART" {
+				ev.start = d
+			} else {
+				ev.end, ev.endTimed = d, timed
+			}
+		case name == "DURATION":
+			ev.duration = value
+		case name == "RRULE":
+			ev.rule = value
+		case name == "RDATE" || name == "EXDATE":
+			for _, v := range strings.Split(value, ",") {
+				d, _, err := icsDate(v)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s line: %q", name, line)
+				}
+				if name == "RDATE" {
+					ev.extra = append(ev.extra, d)
+				} else {
+					ev.except = append(ev.except, d)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// unfoldICS splits b into content lines, joining the continuation lines (those
+// starting with a space or tab) that RFC 5545 folds long lines into.
+func unfoldICS(b []byte) []string {
+	var lines []string
+	for _, l := range strings.Split(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n") {
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// icsDate reads the date of an iCalendar DATE or DATE-TIME value; timed reports a
+// time of day other than midnight.
+func icsDate(v string) (d time.Time, timed bool, err error) {
+	v = strings.TrimSpace(v)
+	if len(v) < 8 {
+		return time.Time{}, false, fmt.Errorf("invalid date %q", v)
+	}
+	d, err = time.Parse("20060102", v[:8])
+	timed = len(v) >= 15 && v[8] == 'T' && v[9:15] != "000000"
+	return d, timed, err
+}
+
+func (ev *icsEvent) addDays(out map[string]bool) error {
+	if ev.start.IsZero() {
+		return errors.New("VEVENT without DTSTART")
+	}
+	days := 1
+	switch {
+	case ev.duration != "":
+		n, err := icsDurationDays(ev.duration)
+		if err != nil {
+			return err
+		}
+		days = max(n, 1)
+	case !ev.end.IsZero():
+		end := ev.end
+		if ev.endTimed {
+			end = end.AddDate(0, 0, 1)
+		}
+		days = max(int(end.Sub(ev.start).Hours()/24), 1)
+	}
+	starts := []time.Time{ev.start}
+	if ev.rule != "" {
+		var err error
+		if starts, err = expandYearly(ev.start, ev.rule); err != nil {
+			return err
+		}
+	}
+	except := map[string]bool{}
+	for _, d := range ev.except {
+		except[d.Format("2006-01-02")] = true
+	}
+	for _, s := range append(starts, ev.extra...) {
+		if except[s.Format("2006-01-02")] {
+			continue
+		}
+		for i := 0; i < days; i++ {
+			out[s.AddDate(0, 0, i).Format("2006-01-02")] = true
+		}
+	}
+	return nil
+}
+
+var icsDurationRe = regexp.MustCompile(`^P(\d+)([DW])$`)
+
+// icsDurationDays reads whole-day DURATION values such as P1D or P2W.
+func icsDurationDays(v string) (int, error) {
+	m := icsDurationRe.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(v)))
+	if m == nil {
+		return 0, fmt.Errorf("unsupported DURATION %q (only whole days or weeks, e.g. P1D)", v)
+	}
+	n, _ := strconv.Atoi(m[1])
+	if m[2] == "W" {
+		n *= 7
+	}
+	return n, nil
+}
+
+// expandYearly lists the dates of an RRULE that repeats start on the same month
+// and day every INTERVAL years, up to COUNT occurrences, UNTIL, or icsRecurYears
+// years. February 29 only recurs in leap years.
+func expandYearly(start time.Time, rule string) ([]time.Time, error) {
+	unsupported := fmt.Errorf("unsupported RRULE %q (only FREQ=YEARLY on a fixed month and day)", rule)
+	interval, count := 1, 0
+	var until time.Time
+	freq := ""
+	for _, part := range strings.Split(strings.ToUpper(rule), ";") {
+		k, v, _ := strings.Cut(part, "=")
+		n, numErr := strconv.Atoi(v)
+		switch {
+		case k == "FREQ":
+			freq = v
+		case k == "INTERVAL" && numErr == nil && n > 0:
+			interval = n
+		case k == "COUNT" && numErr == nil && n > 0:
+			count = n
+		case k == "UNTIL":
+			d, _, err := icsDate(v)
+			if err != nil {
+				return nil, unsupported
+			}
+			until = d
+		case k == "BYMONTH" && numErr == nil && n == int(start.Month()),
+			k == "BYMONTHDAY" && numErr == nil && n == start.Day(),
+			k == "WKST":
+			// restate DTSTART's month and day, or do not matter for yearly dates
+		default:
+			return nil, unsupported
+		}
+	}
+	if freq != "YEARLY" {
+		return nil, unsupported
+	}
+	var out []time.Time
+	for y := start.Year(); y <= start.Year()+icsRecurYears; y += interval {
+		d := time.Date(y, start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+		if d.Month() != start.Month() {
+			continue
+		}
+		if !until.IsZero() && d.After(until) {
+			break
+		}
+		out = append(out, d)
+		if len(out) == count {
+			break
+		}
+	}
+	return out, nil
+}
+
+func parseJSONHolidays(b []byte) (map[string]bool, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of dates: %w", err)
+	}
+	out := map[string]bool{}
+	for _, raw := range items {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			var obj struct {
+				Date string `json:"date"`
+			}
+			if err := json.Unmarshal(raw, &obj); err != nil || obj.Date == "" {
+				return nil, fmt.Errorf("expected a date string or {\"date\": ...}, got %s", raw)
+			}
+			s = obj.Date
+		}
+		if err := addHoliday(out, s); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func parseListHolidays(b []byte) (map[string]bool, error) {
+	out := map[string]bool{}
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "-"))
+		if strings.HasPrefix(line, "date:") {
+			line = strings.TrimSpace(strings.TrimPrefix(line, "date:"))
+		} else if key, _, ok := strings.Cut(line, ":"); ok {
+			key = strings.Trim(strings.TrimSpace(key), `"'`)
+			if _, err := time.Parse("2006-01-02", key); err != nil {
+				continue // other YAML keys such as "name: Christmas"
+			}
+			line = key // a date used as the key, as in "- 2025-12-25: Christmas"
+		}
+		if line == "" {
+			continue
+		}
+		if err := addHoliday(out, strings.Trim(strings.Fields(line)[0], `"'`)); err != nil {
+			return nil, err
+		}
+	}
+	return out, sc.Err()
+}
+
+func addHoliday(out map[string]bool, s string) error {
+	d, err := time.Parse("2006-01-02", strings.TrimSpace(s))
+	if err != nil {
+		return fmt.Errorf("invalid holiday date %q (expected YYYY-MM-DD)", s)
+	}
+	out[d.Format("2006-01-02")] = true
+	return nil
+}
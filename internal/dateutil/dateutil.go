@@ -2,6 +2,7 @@ package dateutil
 
 import (
     "errors"
+    "fmt"
     "strconv"
     "strings"
     "time"
@@ -17,10 +18,18 @@ var CommonLayouts = []string{
     time.ANSIC,
     time.UnixDate,
     time.RubyDate,
+    "2006-01-02T15:04:05Z0700",
+    "2006-01-02T15:04:05",
+    "2006-01-02 15:04:05Z07:00",
     "2006-01-02 15:04:05 MST",
     "2006-01-02 15:04:05 -0700",
     "2006-01-02 15:04:05",
+    "2006-01-02T15:04Z07:00",
+    "2006-01-02T15:04Z0700",
+    "2006-01-02T15:04",
+    "2006-01-02 15:04Z07:00",
     "2006-01-02 15:04 MST",
+    "2006-01-02 15:04 -0700",
     "2006-01-02 15:04",
     "2006-01-02",
 }
@@ -31,30 +40,28 @@ func ParseFlexible(s string, layout string, utc bool) (time.Time, error) {
     if s == "" {
         return time.Time{}, errors.New("empty input")
     }
-    // numeric epoch detection as convenience
-    if isAllDigits(s) {
-        // detect ms vs s
-        if len(s) > 10 {
-            // milliseconds
-            ms, _ := strconv.ParseInt(s, 10, 64)
-            sec := ms / 1000
-            nsec := (ms % 1000) * int64(time.Millisecond)
-            return time.Unix(sec, nsec), nil
-        }
-        sec, _ := strconv.ParseInt(s, 10, 64)
-        return time.Unix(sec, 0), nil
-    }
     var loc *time.Location
     if utc {
         loc = time.UTC
     } else {
         loc = time.Local
     }
-    // custom layout first
+    // custom layout first (Go layout, strftime pattern or preset name), so that
+    // all-digit layouts such as %Y%m%d are not mistaken for epochs
+    var layoutErr error
     if layout != "" {
-        if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+        t, err := parseLayout(layout, s, loc)
+        if err == nil {
             return t, nil
         }
+        layoutErr = err
+    }
+    // numeric epoch detection as convenience
+    if looksLikeEpoch(s) {
+        return ParseEpoch(s, "auto")
+    }
+    if errors.Is(layoutErr, errNoParseDirective) {
+        return time.Time{}, layoutErr
     }
     // try common layouts
     for _, l := range CommonLayouts {
@@ -74,8 +81,90 @@ func isAllDigits(s string) bool {
     return true
 }
 
+// looksLikeEpoch reports whether s is an optionally signed number with at most one decimal point.
+func looksLikeEpoch(s string) bool {
+    s = strings.TrimPrefix(s, "-")
+    intPart, frac, _ := strings.Cut(s, ".")
+    return intPart+frac != "" && isAllDigits(intPart) && isAllDigits(frac)
+}
+
+// EpochUnits lists the accepted epoch units for ParseEpoch.
+var EpochUnits = []string{"auto", "s", "ms", "us", "ns"}
+
+// epochDigits maps an epoch unit to the number of sub-second digits it carries.
+var epochDigits = map[string]int{"s": 0, "ms": 3, "us": 6, "ns": 9}
+
+// DetectEpochUnit guesses the unit of an integer epoch from its magnitude.
+// Values below 1e11 are seconds (good until year 5138), then ms, us and ns.
+func DetectEpochUnit(n int64) string {
+    a := uint64(n)
+    if n < 0 {
+        a = uint64(-(n + 1)) + 1
+    }
+    switch {
+    case a < 1e11:
+        return "s"
+    case a < 1e14:
+        return "ms"
+    case a < 1e17:
+        return "us"
+    default:
+        return "ns"
+    }
+}
+
+// ParseEpoch parses a Unix epoch in the given unit: "s", "ms", "us", "ns" or "auto".
+// Negative values and fractional parts (e.g. "1758112496.123") are accepted;
+// digits finer than a nanosecond are truncated.
+func ParseEpoch(s string, unit string) (time.Time, error) {
+    s = strings.TrimSpace(s)
+    if !looksLikeEpoch(s) {
+        return time.Time{}, fmt.Errorf("invalid epoch value: %s", s)
+    }
+    neg := strings.HasPrefix(s, "-")
+    intPart, frac, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+    if intPart == "" {
+        intPart = "0"
+    }
+    n, err := strconv.ParseInt(intPart, 10, 64)
+    if err != nil {
+        return time.Time{}, fmt.Errorf("epoch value out of range: %s", s)
+    }
+    unit = strings.ToLower(strings.TrimSpace(unit))
+    if unit == "" || unit == "auto" {
+        unit = DetectEpochUnit(n)
+    }
+    digits, ok := epochDigits[unit]
+    if !ok {
+        return time.Time{}, fmt.Errorf("unsupported epoch unit %q (use %s)", unit, strings.Join(EpochUnits, "|"))
+    }
+    scale := pow10(digits)
+    sec := n / scale
+    nsec := (n % scale) * pow10(9-digits)
+    if fracDigits := 9 - digits; frac != "" && fracDigits > 0 {
+        if len(frac) > fracDigits {
+            frac = frac[:fracDigits]
+        }
+        f, _ := strconv.ParseInt(frac+strings.Repeat("0", fracDigits-len(frac)), 10, 64)
+        nsec += f
+    }
+    if neg {
+        sec, nsec = -sec, -nsec
+    }
+    return time.Unix(sec, nsec), nil
+}
+
+func pow10(n int) int64 {
+    p := int64(1)
+    for i := 0; i < n; i++ {
+        p *= 10
+    }
+    return p
+}
+
 // FormatTime renders t in selected format.
-// format: "rfc3339", "unix", "unixms", or "layout" (with layout value).
+// format: "rfc3339", "unix", "unixms", "unixus", "unixns", "layout" (with layout value),
+// a preset name, or a Go layout / strftime pattern used directly.
 func FormatTime(t time.Time, format string, layout string, utc bool) string {
     if utc {
         t = t.UTC()
@@ -87,13 +176,17 @@ func FormatTime(t time.Time, format string, layout string, utc bool) string {
         return strconv.FormatInt(t.Unix(), 10)
     case "unixms":
         return strconv.FormatInt(t.UnixMilli(), 10)
+    case "unixus":
+        return strconv.FormatInt(t.UnixMicro(), 10)
+    case "unixns":
+        return strconv.FormatInt(t.UnixNano(), 10)
     case "layout":
         if layout == "" {
             layout = time.RFC3339
         }
-        return t.Format(layout)
+        return formatLayout(t, layout)
     default:
-        return t.Format(format)
+        return formatLayout(t, format)
     }
 }
 
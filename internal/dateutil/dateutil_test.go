@@ -1,7 +1,10 @@
 package dateutil
 
 import (
+    "os"
+    "path/filepath"
     "strconv"
+    "strings"
     "testing"
     "time"
 )
@@ -42,3 +45,200 @@ func TestFormatVariants(t *testing.T) {
     if FormatTime(ref, "unixms", "", true) != strconv.FormatInt(ref.UnixMilli(), 10) { t.Fatalf("unixms mismatch") }
     if FormatTime(ref, "rfc3339", "", true) != "1970-01-01T00:00:42Z" { t.Fatalf("rfc3339 mismatch") }
 }
+
+func TestParseEpochUnits(t *testing.T) {
+    cases := []struct {
+        in, unit string
+        want     int64 // nanoseconds
+    }{
+        {"1758112496", "auto", 1758112496000000000},
+        {"1758112496123", "auto", 1758112496123000000},
+        {"1758112496123456", "auto", 1758112496123456000},
+        {"1758112496123456789", "auto", 1758112496123456789},
+        {"1758112496.123", "auto", 1758112496123000000},
+        {"1758112496123.5", "auto", 1758112496123500000},
+        {"-1.5", "auto", -1500000000},
+        {"-86400", "s", -86400000000000},
+        {"1500", "ms", 1500000000},
+        {"1500", "us", 1500000},
+        {"1500", "ns", 1500},
+    }
+    for _, c := range cases {
+        got, err := ParseEpoch(c.in, c.unit)
+        if err != nil { t.Fatalf("%s: %v", c.in, err) }
+        if got.UnixNano() != c.want { t.Fatalf("%s (%s): got %d want %d", c.in, c.unit, got.UnixNano(), c.want) }
+    }
+    if _, err := ParseEpoch("12a", "auto"); err == nil { t.Fatalf("expected error for non-numeric input") }
+    if _, err := ParseEpoch("1", "days"); err == nil { t.Fatalf("expected error for unknown unit") }
+}
+
+func TestFormatSubMillisecond(t *testing.T) {
+    ref := time.Unix(1, 2345678)
+    if FormatTime(ref, "unixus", "", true) != "1002345" { t.Fatalf("unixus mismatch") }
+    if FormatTime(ref, "unixns", "", true) != "1002345678" { t.Fatalf("unixns mismatch") }
+}
+
+func TestStrftimeAndPresets(t *testing.T) {
+    ref := time.Date(2025, time.September, 17, 5, 4, 3, 120000000, time.FixedZone("X", 2*3600))
+    cases := map[string]string{
+        "%Y-%m-%d %H:%M:%S": "2025-09-17 05:04:03",
+        "%-d/%-m/%y %I%p":   "17/9/25 05AM",
+        "%S.%L %j %u %a":    "03.120 260 3 Wed",
+        "%G-W%V %%":         "2025-W38 %",
+        "dt=%F/hr=%H":       "dt=2025-09-17/hr=05",
+        "iso8601":           "2025-09-17T05:04:03+02:00",
+        "rfc2822":           "Wed, 17 Sep 2025 05:04:03 +0200",
+        "http":              "Wed, 17 Sep 2025 03:04:03 GMT",
+        "kitchen":           "5:04AM",
+        "sql":               "2025-09-17 05:04:03",
+        "iso-week":          "2025-W38-3",
+        "ordinal":           "2025-260",
+    }
+    for f, want := range cases {
+        if got := FormatTime(ref, f, "", false); got != want { t.Fatalf("%s: got %q want %q", f, got, want) }
+        if got := FormatTime(ref, "layout", f, false); got != want { t.Fatalf("layout %s: got %q want %q", f, got, want) }
+    }
+}
+
+func TestParseStrftimeAndPresets(t *testing.T) {
+    cases := []struct{ layout, in, want string }{
+        {"%d/%m/%Y %H:%M", "17/09/2025 05:04", "2025-09-17T05:04:00Z"},
+        {"%F %T.%L", "2025-09-17 05:04:03.120", "2025-09-17T05:04:03Z"},
+        {"rfc2822", "Wed, 17 Sep 2025 05:04:03 +0200", "2025-09-17T03:04:03Z"},
+        {"iso-week", "2025-W38-3", "2025-09-17T00:00:00Z"},
+        {"iso-week", "2021-W01-1", "2021-01-04T00:00:00Z"},
+        {"ordinal", "2025-260", "2025-09-17T00:00:00Z"},
+    }
+    for _, c := range cases {
+        got, err := ParseFlexible(c.in, c.layout, true)
+        if err != nil { t.Fatalf("%s: %v", c.layout, err) }
+        if s := got.UTC().Format(time.RFC3339); s != c.want { t.Fatalf("%s: got %s want %s", c.layout, s, c.want) }
+    }
+    for _, layout := range []string{"%Y%m%d", "20060102"} {
+        got, err := ParseFlexible("20250917", layout, true)
+        if err != nil || Strftime(got, "%Y%m%d") != "20250917" || got.Unix() != 1758067200 {
+            t.Fatalf("%s: all-digit input should follow the layout, got %v, %v", layout, got, err)
+        }
+    }
+    if _, err := ParseFlexible("1", "%s", true); err != nil { t.Fatalf("epoch input should be accepted when the layout cannot parse: %v", err) }
+    if _, err := ParseFlexible("Wed 3", "%a %u", true); err == nil { t.Fatalf("expected unsupported directive error") }
+    for _, c := range []struct{ layout, in string }{
+        {"%Y-%m-%d v1", "2024-03-05 v1"},
+        {"%d/%m/%Y batch2", "05/03/2024 batch2"},
+    } {
+        if got, err := ParseFlexible(c.in, c.layout, true); err == nil || !strings.Contains(err.Error(), "literal text") {
+            t.Fatalf("%s: literal text with layout tokens should be rejected, got %v, %v", c.layout, got, err)
+        }
+    }
+    if got, err := ParseFlexible("2024-03-05 at noon", "%Y-%m-%d at noon", true); err != nil || got.Day() != 5 {
+        t.Fatalf("plain literal text should still parse, got %v, %v", got, err)
+    }
+}
+
+func TestFindTimestamps(t *testing.T) {
+    line := "req id=42 at 1758112496123 done 2025-09-17T12:34:56Z port 8080 v1234567890123456789012"
+    got := FindTimestamps(line, "auto", true)
+    if len(got) != 2 { t.Fatalf("expected 2 matches, got %+v", got) }
+    if line[got[0].Start:got[0].End] != "1758112496123" || got[0].Time.UnixMilli() != 1758112496123 {
+        t.Fatalf("unexpected epoch match: %+v", got[0])
+    }
+    if line[got[1].Start:got[1].End] != "2025-09-17T12:34:56Z" || got[1].Time.Unix() != 1758112496 {
+        t.Fatalf("unexpected timestamp match: %+v", got[1])
+    }
+    // every form the scanner matches must parse, minute precision included
+    for _, s := range []string{"2025-09-17T12:34", "2025-09-17T12:34Z", "2025-09-17T14:34+02:00", "2025-09-17T14:34+0200", "2025-09-17 12:34 UTC", "2025-09-17 14:34 +0200"} {
+        got := FindTimestamps("at "+s+" done", "auto", true)
+        if len(got) != 1 || got[0].End-got[0].Start != len(s) || got[0].Time.Unix() != 1758112440 {
+            t.Fatalf("%s: expected one full match at 12:34Z, got %+v", s, got)
+        }
+    }
+}
+
+func TestParsePeriod(t *testing.T) {
+    jan31 := time.Date(2025, time.January, 31, 10, 0, 0, 0, time.UTC)
+    cases := map[string]string{
+        "90m":   "2025-01-31T11:30:00Z",
+        "1d":    "2025-02-01T10:00:00Z",
+        "2w":    "2025-02-14T10:00:00Z",
+        "1mo":   "2025-02-28T10:00:00Z",
+        "1y1mo": "2026-02-28T10:00:00Z",
+        "1d12h": "2025-02-01T22:00:00Z",
+        "-1mo":  "2024-12-31T10:00:00Z",
+    }
+    for in, want := range cases {
+        p, err := ParsePeriod(in)
+        if err != nil { t.Fatalf("%s: %v", in, err) }
+        if got := p.AddTo(jan31).Format(time.RFC3339); got != want { t.Fatalf("%s: got %s want %s", in, got, want) }
+    }
+    for _, bad := range []string{"", "1x", "1.5d", "d"} {
+        if _, err := ParsePeriod(bad); err == nil { t.Fatalf("expected error for %q", bad) }
+    }
+}
+
+func TestParseWeekdays(t *testing.T) {
+    set, err := ParseWeekdays("mon-wed,Saturday")
+    if err != nil { t.Fatal(err) }
+    if len(set) != 4 || !set[time.Monday] || !set[time.Wednesday] || !set[time.Saturday] || set[time.Sunday] {
+        t.Fatalf("unexpected set: %v", set)
+    }
+    wrap, err := ParseWeekdays("fri-mon")
+    if err != nil { t.Fatal(err) }
+    if len(wrap) != 4 || !wrap[time.Sunday] { t.Fatalf("unexpected wrapped set: %v", wrap) }
+    if _, err := ParseWeekdays("funday"); err == nil { t.Fatalf("expected error") }
+}
+
+func TestBusinessDays(t *testing.T) {
+    cal := NewCalendar()
+    cal.Holidays["2025-12-25"] = true
+    cal.Holidays["2025-12-26"] = true
+    wed := time.Date(2025, time.December, 24, 9, 0, 0, 0, time.UTC)
+    if got := cal.AddBusinessDays(wed, 2).Format("2006-01-02 15:04"); got != "2025-12-30 09:00" { t.Fatalf("add: got %s", got) }
+    if got := cal.AddBusinessDays(wed, -3).Format("2006-01-02"); got != "2025-12-19" { t.Fatalf("subtract: got %s", got) }
+    sat := time.Date(2025, time.December, 20, 0, 0, 0, 0, time.UTC)
+    if got := cal.AddBusinessDays(sat, 1).Format("2006-01-02"); got != "2025-12-22" { t.Fatalf("from weekend: got %s", got) }
+    end := time.Date(2025, time.December, 30, 0, 0, 0, 0, time.UTC)
+    if n := cal.BusinessDaysBetween(wed, end); n != 2 { t.Fatalf("between: got %d", n) }
+    if n := cal.BusinessDaysBetween(end, wed); n != -2 { t.Fatalf("between reversed: got %d", n) }
+}
+
+func TestLoadHolidays(t *testing.T) {
+    dir := t.TempDir()
+    files := map[string]string{
+        "h.ics":  "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20251225\nEND:VEVENT\nBEGIN:VEVENT\nDTSTART:20260101T000000Z\nEND:VEVENT\nEND:VCALENDAR\n",
+        "h.json": `["2025-12-25", {"date": "2026-01-01", "name": "New Year"}]`,
+        "h.yaml": "holidays:\n  - 2025-12-25 # Christmas\n  - date: \"2026-01-01\"\n    name: New Year\n",
+    }
+    for name, body := range files {
+        path := filepath.Join(dir, name)
+        if err := os.WriteFile(path, []byte(body), 0o644); err != nil { t.Fatal(err) }
+        got, err := LoadHolidays(path)
+        if err != nil { t.Fatalf("%s: %v", name, err) }
+        if len(got) != 2 || !got["2025-12-25"] || !got["2026-01-01"] { t.Fatalf("%s: unexpected holidays %v", name, got) }
+    }
+    load := func(name, body string) (map[string]bool, error) {
+        path := filepath.Join(dir, name)
+        if err := os.WriteFile(path, []byte(body), 0o644); err != nil { t.Fatal(err) }
+        return LoadHolidays(path)
+    }
+    ics := strings.Join([]string{
+        "BEGIN:VCALENDAR",
+        "BEGIN:VTIMEZONE", "BEGIN:STANDARD", "DTSTART:19701025T030000", "RRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU", "END:STANDARD", "END:VTIMEZONE",
+        "BEGIN:VEVENT", "DTSTART;VALUE=DATE:20201225", "DTEND;VALUE=DATE:20201226", "RRULE:FREQ=YEARLY", "EXDATE;VALUE=DATE:20221225", "END:VEVENT",
+        "BEGIN:VEVENT", "DTSTART;VALUE=DATE:20250414", "DTEND;VALUE=DATE:20250417", "END:VEVENT",
+        "BEGIN:VEVENT", "DTSTART;VALUE=DATE:20240229", "RRULE:FREQ=YEARLY;CO", " UNT=2", "END:VEVENT",
+        "END:VCALENDAR",
+    }, "\r\n")
+    got, err := load("rrule.ics", ics)
+    if err != nil { t.Fatal(err) }
+    for _, d := range []string{"2020-12-25", "2025-12-25", "2040-12-25", "2025-04-14", "2025-04-15", "2025-04-16", "2024-02-29", "2028-02-29"} {
+        if !got[d] { t.Fatalf("missing holiday %s", d) }
+    }
+    for _, d := range []string{"2022-12-25", "2025-04-17", "2032-02-29", "2025-10-26", "2025-03-01"} {
+        if got[d] { t.Fatalf("unexpected holiday %s", d) }
+    }
+    if _, err := load("bad.ics", "BEGIN:VEVENT\nDTSTART;VALUE=DATE:20251127\nRRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=4TH\nEND:VEVENT\n"); err == nil {
+        t.Fatal("expected an error for an RRULE that cannot be expanded")
+    }
+    got, err = load("keys.yaml", "holidays:\n  - 2025-12-25: Christmas\n  - \"2026-01-01\": New Year\n  name: ignored\n")
+    if err != nil || len(got) != 2 || !got["2025-12-25"] || !got["2026-01-01"] { t.Fatalf("dates as YAML keys: %v %v", got, err) }
+}
@@ -0,0 +1,148 @@
+package dateutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is a calendar-aware duration: months and days follow the calendar
+// (so "1mo" from Jan 31 lands on Feb 28/29), the remainder is a fixed duration.
+type Period struct {
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+// periodUnits maps calendar units to (months, days); time units are left to time.ParseDuration.
+var periodUnits = map[string][2]int{
+	"y":  {12, 0},
+	"mo": {1, 0},
+	"w":  {0, 7},
+	"d":  {0, 1},
+}
+
+// ParsePeriod parses Go durations extended with calendar units y, mo, w and d,
+// e.g. "1d", "1mo", "2w3d", "1d12h" or "-1y". Calendar units take integer counts.
+func ParsePeriod(s string) (Period, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Period{}, fmt.Errorf("empty period")
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return Period{Duration: d}, nil
+	}
+	rest := s
+	neg := strings.HasPrefix(rest, "-")
+	rest = strings.TrimLeft(rest, "+-")
+	var p Period
+	var clock strings.Builder
+	for rest != "" {
+		i := 0
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.') {
+			i++
+		}
+		j := i
+		for j < len(rest) && !(rest[j] >= '0' && rest[j] <= '9' || rest[j] == '.') {
+			j++
+		}
+		num, unit := rest[:i], rest[i:j]
+		rest = rest[j:]
+		if num == "" || unit == "" {
+			return Period{}, fmt.Errorf("invalid period %q (e.g. 1d, 1mo, 2w, 1h30m)", s)
+		}
+		cal, ok := periodUnits[unit]
+		if !ok {
+			clock.WriteString(num + unit)
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid period %q: %s%s needs a whole number", s, num, unit)
+		}
+		p.Months += n * cal[0]
+		p.Days += n * cal[1]
+	}
+	if clock.Len() > 0 {
+		d, err := time.ParseDuration(clock.String())
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid period %q (e.g. 1d, 1mo, 2w, 1h30m)", s)
+		}
+		p.Duration = d
+	}
+	if neg {
+		p = p.Scale(-1)
+	}
+	return p, nil
+}
+
+// IsZero reports whether p moves time at all.
+func (p Period) IsZero() bool {
+	return p.Months == 0 && p.Days == 0 && p.Duration == 0
+}
+
+// Scale multiplies every component of p by n.
+func (p Period) Scale(n int) Period {
+	return Period{Months: p.Months * n, Days: p.Days * n, Duration: p.Duration * time.Duration(n)}
+}
+
+// AddTo returns t shifted by p. Month steps clamp to the last day of the target month.
+func (p Period) AddTo(t time.Time) time.Time {
+	if p.Months != 0 {
+		y, m, d := t.Date()
+		first := time.Date(y, m+time.Month(p.Months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+		if last := first.AddDate(0, 1, -1).Day(); d > last {
+			d = last
+		}
+		t = first.AddDate(0, 0, d-1)
+	}
+	return t.AddDate(0, 0, p.Days).Add(p.Duration)
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) >= 3 {
+		if d, ok := weekdayNames[s[:3]]; ok && strings.HasPrefix(strings.ToLower(d.String()), s) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+// ParseWeekdays parses a comma-separated weekday list with optional ranges,
+// e.g. "mon-fri", "sat,sun" or "monday,wed-thu". Ranges may wrap (fri-mon).
+func ParseWeekdays(s string) (map[time.Weekday]bool, error) {
+	set := map[time.Weekday]bool{}
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		from, err := parseWeekday(lo)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if isRange {
+			if to, err = parseWeekday(hi); err != nil {
+				return nil, err
+			}
+		}
+		for d := from; ; d = (d + 1) % 7 {
+			set[d] = true
+			if d == to {
+				break
+			}
+		}
+	}
+	if len(set) == 0 {
+		return nil, fmt.Errorf("no weekdays given")
+	}
+	return set, nil
+}
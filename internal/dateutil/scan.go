@@ -0,0 +1,36 @@
+package dateutil
+
+import (
+	"regexp"
+	"time"
+)
+
+// Match is a timestamp found inside free-form text; Start and End are byte offsets.
+type Match struct {
+	Start, End int
+	Time       time.Time
+}
+
+// timestampRe finds ISO-8601-like timestamps (group 1) and 10-19 digit epochs (group 2).
+var timestampRe = regexp.MustCompile(`(\d{4}-\d{2}-\d{2}(?:[T ]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?)?(?:Z|[+-]\d{2}:?\d{2}| [+-]\d{4}| UTC| GMT)?)?)|\b(\d{10,19}(?:\.\d{1,9})?)\b`)
+
+// FindTimestamps returns the epoch numbers and ISO-8601-like timestamps in s that
+// parse successfully. Epochs are read in unit (see ParseEpoch); other timestamps go
+// through ParseFlexible, so utc controls how zone-less values are interpreted.
+func FindTimestamps(s string, unit string, utc bool) []Match {
+	var out []Match
+	for _, m := range timestampRe.FindAllStringSubmatchIndex(s, -1) {
+		var t time.Time
+		var err error
+		if m[2] >= 0 {
+			t, err = ParseFlexible(s[m[2]:m[3]], "", utc)
+		} else {
+			t, err = ParseEpoch(s[m[4]:m[5]], unit)
+		}
+		if err != nil {
+			continue
+		}
+		out = append(out, Match{Start: m[0], End: m[1], Time: t})
+	}
+	return out
+}
@@ -0,0 +1,276 @@
+package dateutil
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Presets maps named formats to strftime patterns.
+var Presets = map[string]string{
+	"iso8601":  "%Y-%m-%dT%H:%M:%S%:z",
+	"rfc2822":  "%a, %d %b %Y %H:%M:%S %z",
+	"http":     "%a, %d %b %Y %H:%M:%S GMT",
+	"kitchen":  "%-I:%M%p",
+	"sql":      "%Y-%m-%d %H:%M:%S",
+	"iso-week": "%G-W%V-%u",
+	"ordinal":  "%Y-%j",
+}
+
+// FormatNames lists the named values accepted by FormatTime, presets included.
+func FormatNames() []string {
+	names := []string{"rfc3339", "unix", "unixms", "unixus", "unixns", "layout"}
+	presets := make([]string, 0, len(Presets))
+	for k := range Presets {
+		presets = append(presets, k)
+	}
+	sort.Strings(presets)
+	return append(names, presets...)
+}
+
+// formatLayout renders t with a preset name, a strftime pattern or a Go layout.
+func formatLayout(t time.Time, layout string) string {
+	if p, ok := Presets[strings.ToLower(layout)]; ok {
+		if strings.ToLower(layout) == "http" {
+			t = t.UTC()
+		}
+		return Strftime(t, p)
+	}
+	if strings.Contains(layout, "%") {
+		return Strftime(t, layout)
+	}
+	return t.Format(layout)
+}
+
+// parseLayout parses s with a preset name, a strftime pattern or a Go layout.
+func parseLayout(layout, s string, loc *time.Location) (time.Time, error) {
+	if p, ok := Presets[strings.ToLower(layout)]; ok {
+		layout = p
+	}
+	if layout == Presets["iso-week"] {
+		return parseISOWeek(s, loc)
+	}
+	if strings.Contains(layout, "%") {
+		l, err := StrftimeLayout(layout)
+		if err != nil {
+			return time.Time{}, err
+		}
+		layout = l
+	}
+	return time.ParseInLocation(layout, s, loc)
+}
+
+// Strftime formats t using C strftime directives such as %Y-%m-%d %H:%M:%S.
+// A '-' after '%' drops zero padding (e.g. %-d); %L, %f and %N print
+// milliseconds, microseconds and nanoseconds.
+func Strftime(t time.Time, pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c != '%' || i+1 >= len(pattern) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		pad := true
+		if pattern[i] == '-' && i+1 < len(pattern) {
+			pad = false
+			i++
+		}
+		num := func(n, width int) {
+			s := strconv.Itoa(n)
+			if pad && len(s) < width {
+				s = strings.Repeat("0", width-len(s)) + s
+			}
+			b.WriteString(s)
+		}
+		spaced := func(n int) {
+			if pad && n < 10 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strconv.Itoa(n))
+		}
+		switch pattern[i] {
+		case 'Y':
+			num(t.Year(), 4)
+		case 'y':
+			num(t.Year()%100, 2)
+		case 'C':
+			num(t.Year()/100, 2)
+		case 'G':
+			y, _ := t.ISOWeek()
+			num(y, 4)
+		case 'g':
+			y, _ := t.ISOWeek()
+			num(y%100, 2)
+		case 'm':
+			num(int(t.Month()), 2)
+		case 'd':
+			num(t.Day(), 2)
+		case 'e':
+			spaced(t.Day())
+		case 'j':
+			num(t.YearDay(), 3)
+		case 'H':
+			num(t.Hour(), 2)
+		case 'k':
+			spaced(t.Hour())
+		case 'I':
+			num(hour12(t), 2)
+		case 'l':
+			spaced(hour12(t))
+		case 'M':
+			num(t.Minute(), 2)
+		case 'S':
+			num(t.Second(), 2)
+		case 'L':
+			num(t.Nanosecond()/1e6, 3)
+		case 'f':
+			num(t.Nanosecond()/1e3, 6)
+		case 'N':
+			num(t.Nanosecond(), 9)
+		case 'p':
+			b.WriteString(t.Format("PM"))
+		case 'P':
+			b.WriteString(t.Format("pm"))
+		case 'a':
+			b.WriteString(t.Format("Mon"))
+		case 'A':
+			b.WriteString(t.Weekday().String())
+		case 'b', 'h':
+			b.WriteString(t.Format("Jan"))
+		case 'B':
+			b.WriteString(t.Month().String())
+		case 'u':
+			b.WriteString(strconv.Itoa((int(t.Weekday())+6)%7 + 1))
+		case 'w':
+			b.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'V':
+			_, w := t.ISOWeek()
+			num(w, 2)
+		case 'U':
+			num((t.YearDay()+6-int(t.Weekday()))/7, 2)
+		case 'W':
+			num((t.YearDay()+6-(int(t.Weekday())+6)%7)/7, 2)
+		case 'z':
+			b.WriteString(t.Format("-0700"))
+		case ':':
+			if i+1 < len(pattern) && pattern[i+1] == 'z' {
+				i++
+				b.WriteString(t.Format("-07:00"))
+			} else {
+				b.WriteString("%:")
+			}
+		case 'Z':
+			b.WriteString(t.Format("MST"))
+		case 's':
+			b.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'F':
+			b.WriteString(Strftime(t, "%Y-%m-%d"))
+		case 'T':
+			b.WriteString(Strftime(t, "%H:%M:%S"))
+		case 'R':
+			b.WriteString(Strftime(t, "%H:%M"))
+		case 'D', 'x':
+			b.WriteString(Strftime(t, "%m/%d/%y"))
+		case 'X':
+			b.WriteString(Strftime(t, "%H:%M:%S"))
+		case 'c':
+			b.WriteString(Strftime(t, "%a %b %e %H:%M:%S %Y"))
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			if !pad {
+				b.WriteByte('-')
+			}
+			b.WriteByte(pattern[i])
+		}
+	}
+	return b.String()
+}
+
+func hour12(t time.Time) int {
+	h := t.Hour() % 12
+	if h == 0 {
+		h = 12
+	}
+	return h
+}
+
+// errNoParseDirective marks strftime directives, and literal text, that cannot
+// be used for parsing.
+var errNoParseDirective = errors.New("not supported for parsing")
+
+// strftimeLayouts maps strftime directives to Go reference layout fragments.
+var strftimeLayouts = map[string]string{
+	"Y": "2006", "y": "06", "m": "01", "-m": "1", "d": "02", "-d": "2", "e": "_2",
+	"j": "002", "H": "15", "I": "03", "-I": "3", "M": "04", "-M": "4", "S": "05", "-S": "5",
+	"L": "000", "f": "000000", "N": "000000000",
+	"p": "PM", "P": "pm", "a": "Mon", "A": "Monday", "b": "Jan", "h": "Jan", "B": "January",
+	"z": "-0700", ":z": "Z07:00", "Z": "MST",
+	"F": "2006-01-02", "T": "15:04:05", "R": "15:04", "D": "01/02/06", "n": "\n", "t": "\t", "%": "%",
+}
+
+// goLayoutTokenRe matches text a Go layout would read as a date field rather
+// than literally; Go layouts have no way to escape it.
+var goLayoutTokenRe = regexp.MustCompile(`[0-9]|Jan|Mon|MST|PM|pm`)
+
+// StrftimeLayout translates a strftime pattern into a Go layout for parsing.
+// Directives without a Go equivalent (e.g. %s, %u, %V) are rejected, and so is
+// literal text that Go would mistake for a field, such as "v1" or "Monthly".
+func StrftimeLayout(pattern string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' || i+1 >= len(pattern) {
+			j := i + 1
+			for j < len(pattern) && (pattern[j] != '%' || j+1 >= len(pattern)) {
+				j++
+			}
+			if tok := goLayoutTokenRe.FindString(pattern[i:j]); tok != "" {
+				return "", fmt.Errorf("literal text %q is %w: Go layouts read %q as a date field", pattern[i:j], errNoParseDirective, tok)
+			}
+			b.WriteString(pattern[i:j])
+			i = j - 1
+			continue
+		}
+		d := pattern[i+1 : i+2]
+		if (d == "-" || d == ":") && i+2 < len(pattern) {
+			d = pattern[i+1 : i+3]
+		}
+		l, ok := strftimeLayouts[d]
+		if !ok {
+			return "", fmt.Errorf("strftime directive %%%s is %w", d, errNoParseDirective)
+		}
+		b.WriteString(l)
+		i += len(d)
+	}
+	return b.String(), nil
+}
+
+var isoWeekRe = regexp.MustCompile(`^(\d{4})-?W(\d{2})-?([1-7])$`)
+
+// parseISOWeek parses ISO week dates such as 2025-W38-3 (midnight in loc).
+func parseISOWeek(s string, loc *time.Location) (time.Time, error) {
+	m := isoWeekRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return time.Time{}, fmt.Errorf("invalid ISO week date %q (expected YYYY-Www-D)", s)
+	}
+	year, _ := strconv.Atoi(m[1])
+	week, _ := strconv.Atoi(m[2])
+	day, _ := strconv.Atoi(m[3])
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	if _, last := time.Date(year, time.December, 28, 0, 0, 0, 0, loc).ISOWeek(); week < 1 || week > last {
+		return time.Time{}, fmt.Errorf("ISO week %d out of range for %d", week, year)
+	}
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, (week-1)*7+day-1), nil
+}
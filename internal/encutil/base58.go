@@ -0,0 +1,68 @@
+package encutil
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Base58Alphabet is the Bitcoin alphabet (no 0, O, I or l).
+const Base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base58Alphabets maps the common Base58 variants to their alphabets.
+var Base58Alphabets = map[string]string{
+	"bitcoin": Base58Alphabet,
+	"flickr":  "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ",
+	"ripple":  "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz",
+}
+
+// Base58Encode encodes b with the Bitcoin alphabet; leading zero bytes become '1's.
+func Base58Encode(b []byte) string {
+	return Base58EncodeWith(b, Base58Alphabet)
+}
+
+// Base58Decode reverses Base58Encode.
+func Base58Decode(s string) ([]byte, error) {
+	return Base58DecodeWith(s, Base58Alphabet)
+}
+
+// Base58EncodeWith encodes b with a 58-character alphabet; leading zero bytes
+// become the alphabet's first character.
+func Base58EncodeWith(b []byte, alphabet string) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	n := new(big.Int).SetBytes(b)
+	base, mod := big.NewInt(58), new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		out = append(out, alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// Base58DecodeWith reverses Base58EncodeWith.
+func Base58DecodeWith(s, alphabet string) ([]byte, error) {
+	n := new(big.Int)
+	base := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(alphabet, s[i])
+		if v < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q at offset %d", s[i], i)
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(v)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
@@ -0,0 +1,107 @@
+package encutil
+
+import (
+	"encoding/ascii85"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Base85Alphabet is the RFC 1924 alphabet used by git binary patches and Python's b85encode.
+const Base85Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~"
+
+// Z85Alphabet is the ZeroMQ Z85 alphabet, chosen to be safe inside quoted strings.
+const Z85Alphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#"
+
+// Ascii85Encode encodes b as btoa/Adobe Ascii85, using 'z' for all-zero groups.
+// adobe wraps the result in the "<~" and "~>" delimiters.
+func Ascii85Encode(b []byte, adobe bool) string {
+	out := make([]byte, ascii85.MaxEncodedLen(len(b)))
+	out = out[:ascii85.Encode(out, b)]
+	if adobe {
+		return "<~" + string(out) + "~>"
+	}
+	return string(out)
+}
+
+// Ascii85Decode reverses Ascii85Encode. The Adobe delimiters and whitespace are optional.
+func Ascii85Decode(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "<~"), "~>")
+	out := make([]byte, 4*len(s))
+	n, _, err := ascii85.Decode(out, []byte(s), true)
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
+
+// Base85Encode encodes b with the RFC 1924 alphabet. A short final group is
+// zero-padded and the padding characters are dropped, as in Python's b85encode.
+func Base85Encode(b []byte) string {
+	return encode85(b, Base85Alphabet)
+}
+
+// Base85Decode reverses Base85Encode.
+func Base85Decode(s string) ([]byte, error) {
+	return decode85(s, Base85Alphabet, "base85")
+}
+
+// Z85Encode encodes b as Z85, whose specification requires a multiple of 4 bytes.
+func Z85Encode(b []byte) (string, error) {
+	if len(b)%4 != 0 {
+		return "", fmt.Errorf("z85 input must be a multiple of 4 bytes, got %d", len(b))
+	}
+	return encode85(b, Z85Alphabet), nil
+}
+
+// Z85Decode reverses Z85Encode; s must be a multiple of 5 characters.
+func Z85Decode(s string) ([]byte, error) {
+	if len(s)%5 != 0 {
+		return nil, fmt.Errorf("z85 input must be a multiple of 5 characters, got %d", len(s))
+	}
+	return decode85(s, Z85Alphabet, "z85")
+}
+
+// encode85 writes each big-endian 4-byte group as 5 digits, most significant first.
+func encode85(b []byte, alphabet string) string {
+	var out []byte
+	for i := 0; i < len(b); i += 4 {
+		var group [4]byte
+		n := copy(group[:], b[i:])
+		v := uint32(group[0])<<24 | uint32(group[1])<<16 | uint32(group[2])<<8 | uint32(group[3])
+		var digits [5]byte
+		for j := 4; j >= 0; j-- {
+			digits[j] = alphabet[v%85]
+			v /= 85
+		}
+		out = append(out, digits[:n+1]...)
+	}
+	return string(out)
+}
+
+func decode85(s, alphabet, name string) ([]byte, error) {
+	if len(s)%5 == 1 {
+		return nil, errors.New(name + ": truncated input")
+	}
+	var out []byte
+	for i := 0; i < len(s); i += 5 {
+		n := min(5, len(s)-i)
+		var v uint64
+		for j := 0; j < 5; j++ {
+			d := len(alphabet) - 1 // pad a short final group with the highest digit
+			if j < n {
+				if d = strings.IndexByte(alphabet, s[i+j]); d < 0 {
+					return nil, fmt.Errorf("invalid %s character %q at offset %d", name, s[i+j], i+j)
+				}
+			}
+			v = v*85 + uint64(d)
+		}
+		if v > 1<<32-1 {
+			return nil, fmt.Errorf("%s group at offset %d overflows 32 bits", name, i)
+		}
+		group := []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+		out = append(out, group[:n-1]...)
+	}
+	return out, nil
+}
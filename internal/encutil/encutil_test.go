@@ -0,0 +1,87 @@
+package encutil
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	cases := map[string]string{
+		"":            "",
+		"hello world": "StV1DL6CwTryKyV",
+		"\x00\x00abc": "11ZiCa",
+	}
+	for in, want := range cases {
+		if got := Base58Encode([]byte(in)); got != want {
+			t.Fatalf("encode %q: got %q want %q", in, got, want)
+		}
+		back, err := Base58Decode(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(back, []byte(in)) {
+			t.Fatalf("decode %q: got %q", want, back)
+		}
+	}
+	if _, err := Base58Decode("0OIl"); err == nil {
+		t.Fatalf("expected error for characters outside the alphabet")
+	}
+}
+
+func TestBase58Alphabets(t *testing.T) {
+	for name, alphabet := range Base58Alphabets {
+		enc := Base58EncodeWith([]byte("\x00hello world"), alphabet)
+		if enc[0] != alphabet[0] {
+			t.Fatalf("%s: leading zero byte should encode as %q, got %q", name, alphabet[0], enc)
+		}
+		back, err := Base58DecodeWith(enc, alphabet)
+		if err != nil || string(back) != "\x00hello world" {
+			t.Fatalf("%s: round trip gave %q, %v", name, back, err)
+		}
+	}
+	if got := Base58EncodeWith([]byte("hello world"), Base58Alphabets["flickr"]); got != "rTu1dk6cWsRYjYu" {
+		t.Fatalf("flickr: got %q", got)
+	}
+}
+
+func TestBase85(t *testing.T) {
+	// Expected values from Python's base64.b85encode and a85encode.
+	cases := []struct {
+		in, want string
+		encode   func([]byte) string
+		decode   func(string) ([]byte, error)
+	}{
+		{"hello world", "Xk~0{Zy<MXa%^M", Base85Encode, Base85Decode},
+		{"\x00\x00\x00\x00abc", "00000VPaz", Base85Encode, Base85Decode},
+		{"hello world", "<~BOu!rD]j7BEbo7~>", func(b []byte) string { return Ascii85Encode(b, true) }, Ascii85Decode},
+		{"\x00\x00\x00\x00ab", "z@:B", func(b []byte) string { return Ascii85Encode(b, false) }, Ascii85Decode},
+	}
+	for _, c := range cases {
+		if got := c.encode([]byte(c.in)); got != c.want {
+			t.Fatalf("encode %q: got %q want %q", c.in, got, c.want)
+		}
+		back, err := c.decode(c.want)
+		if err != nil || string(back) != c.in {
+			t.Fatalf("decode %q: got %q, %v", c.want, back, err)
+		}
+	}
+	if _, err := Base85Decode("~~~~~"); err == nil {
+		t.Fatal("expected an overflow error")
+	}
+}
+
+func TestZ85(t *testing.T) {
+	// Test vector from the ZeroMQ Z85 specification (RFC 32).
+	in := []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}
+	got, err := Z85Encode(in)
+	if err != nil || got != "HelloWorld" {
+		t.Fatalf("encode: got %q, %v", got, err)
+	}
+	back, err := Z85Decode("HelloWorld")
+	if err != nil || !bytes.Equal(back, in) {
+		t.Fatalf("decode: got %x, %v", back, err)
+	}
+	if _, err := Z85Encode([]byte("abc")); err == nil {
+		t.Fatal("expected an error for input that is not a multiple of 4 bytes")
+	}
+}
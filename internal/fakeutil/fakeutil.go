@@ -0,0 +1,313 @@
+package fakeutil
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"dt/internal/dateutil"
+	"dt/internal/idutil"
+	"dt/internal/randutil"
+	"dt/internal/uuidutil"
+)
+
+var (
+	firstNames = []string{
+		"Ada", "Alan", "Amara", "Ana", "Arjun", "Beatriz", "Chen", "Chloe", "Daniel", "Elena",
+		"Emeka", "Fatima", "Freya", "Grace", "Hana", "Hugo", "Ines", "Isaac", "Jamal", "Julia",
+		"Kai", "Kenji", "Lars", "Leila", "Liam", "Lucia", "Maya", "Mateo", "Nadia", "Noah",
+		"Olga", "Omar", "Priya", "Rafael", "Sara", "Sofia", "Tariq", "Yara", "Yusuf", "Zoe",
+	}
+	lastNames = []string{
+		"Adeyemi", "Andersen", "Bauer", "Costa", "Dubois", "Evans", "Fernandez", "Garcia", "Haddad", "Ivanova",
+		"Jensen", "Kim", "Kowalski", "Lopez", "Martin", "Meyer", "Moreau", "Nakamura", "Novak", "Okafor",
+		"Olsen", "Patel", "Popescu", "Rossi", "Santos", "Schmidt", "Silva", "Smith", "Tanaka", "Wang",
+	}
+	cities = []string{
+		"Amsterdam", "Austin", "Berlin", "Bogota", "Cairo", "Cape Town", "Chicago", "Dublin", "Helsinki", "Lagos",
+		"Lisbon", "London", "Madrid", "Melbourne", "Mumbai", "Nairobi", "Osaka", "Paris", "Seoul", "Toronto",
+	}
+	countries = []string{
+		"AR", "AU", "BR", "CA", "DE", "EG", "ES", "FI", "FR", "GB",
+		"IE", "IN", "IT", "JP", "KE", "KR", "MX", "NG", "NL", "PT", "SE", "US", "ZA",
+	}
+	// domains are reserved for documentation (RFC 2606), so fake addresses never reach anyone
+	domains = []string{"example.com", "example.net", "example.org"}
+)
+
+// Faker produces random values by name. All randomness comes from Rand and all
+// times are relative to Now, so a seeded Rand and fixed Now give repeatable data.
+type Faker struct {
+	Rand io.Reader
+	Now  func() time.Time
+
+	row   int
+	uuids *uuidutil.Generator
+	ids   *idutil.Generator
+}
+
+// New returns a Faker drawing from r and the clock now.
+func New(r io.Reader, now func() time.Time) *Faker {
+	return &Faker{
+		Rand:  r,
+		Now:   now,
+		uuids: &uuidutil.Generator{Rand: r, Now: now},
+		ids:   &idutil.Generator{Rand: r, Now: now},
+	}
+}
+
+// NextRow advances the counter returned by the "seq" generator.
+func (f *Faker) NextRow() { f.row++ }
+
+// generator produces a value from optional string arguments.
+type generator struct {
+	usage string
+	fn    func(f *Faker, args []string) (any, error)
+}
+
+var generators = map[string]generator{
+	"uuid":       {"random UUID (v4)", func(f *Faker, _ []string) (any, error) { return str(f.uuids.NewV4()) }},
+	"uuid7":      {"time-ordered UUID (v7)", func(f *Faker, _ []string) (any, error) { return str(f.uuids.NewV7()) }},
+	"ulid":       {"ULID", func(f *Faker, _ []string) (any, error) { return f.ids.ULID() }},
+	"seq":        {"row number starting at 1", func(f *Faker, _ []string) (any, error) { return int64(f.row), nil }},
+	"first_name": {"given name", func(f *Faker, _ []string) (any, error) { return f.pick(firstNames) }},
+	"last_name":  {"family name", func(f *Faker, _ []string) (any, error) { return f.pick(lastNames) }},
+	"name":       {"full name", (*Faker).name},
+	"username":   {"lower-case handle", (*Faker).username},
+	"email":      {"address at a reserved example domain", (*Faker).email},
+	"word":       {"diceware word", func(f *Faker, _ []string) (any, error) { return f.pick(randutil.Words()) }},
+	"city":       {"city name", func(f *Faker, _ []string) (any, error) { return f.pick(cities) }},
+	"country":    {"ISO 3166 alpha-2 code", func(f *Faker, _ []string) (any, error) { return f.pick(countries) }},
+	"phone":      {"E.164 number in the +1 555 fictional range", (*Faker).phone},
+	"ipv4":       {"address from the TEST-NET documentation ranges", (*Faker).ipv4},
+	"url":        {"https URL at a reserved example domain", (*Faker).url},
+	"hex":        {"hex string: [bytes] (default 8)", (*Faker).hex},
+	"int":        {"integer: [min max] (default 0 100)", (*Faker).int},
+	"float":      {"number with 2 decimals: [min max] (default 0 100)", (*Faker).float},
+	"bool":       {"true or false", func(f *Faker, _ []string) (any, error) { return f.pickAny([]any{true, false}) }},
+	"pick":       {"one of the arguments", func(f *Faker, args []string) (any, error) { return f.pick(args) }},
+	"date":       {"time within the past year: [format] (default rfc3339)", (*Faker).date},
+	"now":        {"current time: [format] (default rfc3339)", (*Faker).now},
+}
+
+// Names returns the generator names with a short description, sorted.
+func Names() []string {
+	out := make([]string, 0, len(generators))
+	for name, g := range generators {
+		out = append(out, name+"\t"+g.usage)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Value runs the generator called name.
+func (f *Faker) Value(name string, args []string) (any, error) {
+	g, ok := generators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown fake generator %q", name)
+	}
+	return g.fn(f, args)
+}
+
+func str[T fmt.Stringer](v T, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return v.String(), nil
+}
+
+func (f *Faker) intn(n int) (int, error) {
+	v, err := randutil.Uint64n(f.Rand, uint64(n))
+	return int(v), err
+}
+
+func (f *Faker) pick(list []string) (any, error) {
+	if len(list) == 0 {
+		return nil, fmt.Errorf("nothing to pick from")
+	}
+	i, err := f.intn(len(list))
+	if err != nil {
+		return nil, err
+	}
+	return list[i], nil
+}
+
+func (f *Faker) pickAny(list []any) (any, error) {
+	i, err := f.intn(len(list))
+	if err != nil {
+		return nil, err
+	}
+	return list[i], nil
+}
+
+func (f *Faker) name(_ []string) (any, error) {
+	first, err := f.pick(firstNames)
+	if err != nil {
+		return nil, err
+	}
+	last, err := f.pick(lastNames)
+	if err != nil {
+		return nil, err
+	}
+	return first.(string) + " " + last.(string), nil
+}
+
+func (f *Faker) username(_ []string) (any, error) {
+	first, err := f.pick(firstNames)
+	if err != nil {
+		return nil, err
+	}
+	n, err := f.intn(1000)
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("%s%d", strings.ToLower(first.(string)), n), nil
+}
+
+func (f *Faker) email(_ []string) (any, error) {
+	first, err := f.pick(firstNames)
+	if err != nil {
+		return nil, err
+	}
+	last, err := f.pick(lastNames)
+	if err != nil {
+		return nil, err
+	}
+	domain, err := f.pick(domains)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToLower(first.(string)+"."+last.(string)) + "@" + domain.(string), nil
+}
+
+func (f *Faker) phone(_ []string) (any, error) {
+	n, err := f.intn(100)
+	if err != nil {
+		return nil, err
+	}
+	area, err := f.intn(800)
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("+1%03d55501%02d", area+200, n), nil
+}
+
+func (f *Faker) ipv4(_ []string) (any, error) {
+	prefix, err := f.pick([]string{"192.0.2", "198.51.100", "203.0.113"})
+	if err != nil {
+		return nil, err
+	}
+	host, err := f.intn(254)
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("%s.%d", prefix, host+1), nil
+}
+
+func (f *Faker) url(_ []string) (any, error) {
+	domain, err := f.pick(domains)
+	if err != nil {
+		return nil, err
+	}
+	word, err := f.pick(randutil.Words())
+	if err != nil {
+		return nil, err
+	}
+	return "https://" + domain.(string) + "/" + word.(string), nil
+}
+
+func (f *Faker) hex(args []string) (any, error) {
+	n := 8
+	if len(args) > 0 {
+		v, err := strconv.Atoi(args[0])
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("hex: invalid byte count %q", args[0])
+		}
+		n = v
+	}
+	b, err := randutil.Bytes(f.Rand, n)
+	if err != nil {
+		return nil, err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (f *Faker) int(args []string) (any, error) {
+	lo, hi, err := bounds("int", args, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+	return randutil.IntRange(f.Rand, int64(math.Ceil(lo)), int64(math.Floor(hi)))
+}
+
+func (f *Faker) float(args []string) (any, error) {
+	lo, hi, err := bounds("float", args, 1e15)
+	if err != nil {
+		return nil, err
+	}
+	return f.Float(lo, hi)
+}
+
+// Float returns a number in [lo, hi] rounded to two decimals.
+func (f *Faker) Float(lo, hi float64) (float64, error) {
+	v, err := randutil.IntRange(f.Rand, int64(math.Ceil(lo*100)), int64(math.Floor(hi*100)))
+	return float64(v) / 100, err
+}
+
+// bounds parses [min max], which must lie within ±limit so they convert to int64.
+func bounds(name string, args []string, limit float64) (float64, float64, error) {
+	if len(args) == 0 {
+		return 0, 100, nil
+	}
+	if len(args) != 2 {
+		return 0, 0, fmt.Errorf("%s: expected min and max, got %d arguments", name, len(args))
+	}
+	lo, err1 := strconv.ParseFloat(args[0], 64)
+	hi, err2 := strconv.ParseFloat(args[1], 64)
+	if err1 != nil || err2 != nil {
+		return 0, 0, fmt.Errorf("%s: invalid bounds %q %q", name, args[0], args[1])
+	}
+	for _, v := range []float64{lo, hi} {
+		if !(v > -limit && v < limit) {
+			return 0, 0, fmt.Errorf("%s: bound %v is out of range (±%.4g)", name, v, limit)
+		}
+	}
+	if lo > hi {
+		return 0, 0, fmt.Errorf("%s: min %v is greater than max %v", name, lo, hi)
+	}
+	return lo, hi, nil
+}
+
+// Time returns a random instant within the year before Now.
+func (f *Faker) Time() (time.Time, error) {
+	s, err := randutil.Uint64n(f.Rand, 365*24*3600)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return f.Now().Add(-time.Duration(s) * time.Second).UTC().Truncate(time.Second), nil
+}
+
+func (f *Faker) date(args []string) (any, error) {
+	t, err := f.Time()
+	if err != nil {
+		return nil, err
+	}
+	return formatTime(t, args), nil
+}
+
+func (f *Faker) now(args []string) (any, error) {
+	return formatTime(f.Now().UTC(), args), nil
+}
+
+func formatTime(t time.Time, args []string) string {
+	format := "rfc3339"
+	if len(args) > 0 {
+		format = strings.Join(args, " ")
+	}
+	return dateutil.FormatTime(t, format, "", true)
+}
@@ -0,0 +1,115 @@
+package fakeutil
+
+import (
+	"encoding/json"
+	"math/rand"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"dt/internal/jsonutil"
+)
+
+func newTestFaker(seed int64) *Faker {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	return New(rand.New(rand.NewSource(seed)), func() time.Time { return now })
+}
+
+func TestTemplate(t *testing.T) {
+	tmpl, err := ParseTemplate(`{"id":"{{uuid}}","age":{{int 18 20}},"day":"{{date "%Y-%m-%d"}}","n":{{seq}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newTestFaker(1)
+	re := regexp.MustCompile(`^\{"id":"[0-9a-f-]{36}","age":(18|19|20),"day":"202[34]-\d\d-\d\d","n":2\}$`)
+	var first string
+	for i := 0; i < 2; i++ {
+		f.NextRow()
+		out, err := tmpl.Execute(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			first = out
+			continue
+		}
+		if !re.MatchString(out) || !json.Valid([]byte(out)) {
+			t.Fatalf("unexpected record %q", out)
+		}
+	}
+	again, _ := tmpl.Execute(func() *Faker { f := newTestFaker(1); f.NextRow(); return f }())
+	if again != first {
+		t.Fatalf("same seed gave %q then %q", first, again)
+	}
+	for _, bad := range []string{"{{nope}}", "{{uuid", `{{date "unterminated}}`} {
+		if _, err := ParseTemplate(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestTemplate_JSONEscaping(t *testing.T) {
+	tmpl, err := ParseTemplate(`{"q":"say {{pick "a\"b\\c"}}!","bare":{{pick "x y"}},"n":{{pick 7}},"esc\"{{pick "}"}}":1}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := tmpl.Execute(newTestFaker(1))
+	want := `{"q":"say a\"b\\c!","bare":"x y","n":7,"esc\"}":1}`
+	if err != nil || out != want || !json.Valid([]byte(out)) {
+		t.Fatalf("got %q err %v, want %q", out, err, want)
+	}
+	plain, _ := ParseTemplate(`name={{pick "a\"b"}}`)
+	if out, _ := plain.Execute(newTestFaker(1)); out != `name=a"b` {
+		t.Fatalf("non-JSON templates should substitute verbatim, got %q", out)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	f := newTestFaker(1)
+	for _, c := range []struct{ gen, lo, hi string }{
+		{"int", "0", "1e19"},
+		{"int", "-1e19", "0"},
+		{"int", "NaN", "1"},
+		{"int", "5", "1"},
+		{"float", "0", "1e17"},
+		{"float", "0", "Inf"},
+	} {
+		if v, err := f.Value(c.gen, []string{c.lo, c.hi}); err == nil {
+			t.Fatalf("%s %s %s: expected an error, got %v", c.gen, c.lo, c.hi, v)
+		}
+	}
+	if v, err := f.Value("int", []string{"-9e18", "9e18"}); err != nil {
+		t.Fatalf("int within int64 range: %v %v", v, err)
+	}
+}
+
+func TestFromSchema(t *testing.T) {
+	schema, err := jsonutil.DecodeOrdered(strings.NewReader(`{
+		"type": "object",
+		"properties": {
+			"z": {"type": "integer", "minimum": 5, "maximum": 5},
+			"email": {"type": "string", "format": "email"},
+			"role": {"enum": ["admin"]},
+			"tags": {"type": "array", "items": {"const": "x"}, "minItems": 2, "maxItems": 2},
+			"who": {"x-fake": "pick alice"},
+			"note": {"type": ["null", "string"], "maxLength": 3}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := newTestFaker(1).FromSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(v)
+	re := regexp.MustCompile(`^\{"z":5,"email":"[a-z]+\.[a-z]+@example\.(com|net|org)","role":"admin","tags":\["x","x"\],"who":"alice","note":"[a-z-]{1,3}"\}$`)
+	if !re.Match(b) {
+		t.Fatalf("unexpected record %s", b)
+	}
+	bad, _ := jsonutil.DecodeOrdered(strings.NewReader(`{"$ref": "#/defs/x"}`))
+	if _, err := newTestFaker(1).FromSchema(bad); err == nil {
+		t.Fatal("expected error for $ref")
+	}
+}
@@ -0,0 +1,189 @@
+package fakeutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"dt/internal/jsonutil"
+	"dt/internal/randutil"
+)
+
+// FromSchema generates a value matching a JSON Schema decoded with
+// jsonutil.DecodeOrdered, so object properties keep their declared order.
+// Supported keywords: type, properties, items, minItems, maxItems, enum, const,
+// format, minLength, maxLength, minimum, maximum and the "x-fake" extension, which
+// names a generator (optionally with arguments) to use for the value.
+func (f *Faker) FromSchema(schema any) (any, error) {
+	s, ok := schema.(*jsonutil.Ordered)
+	if !ok {
+		if b, isBool := schema.(bool); isBool && b {
+			return f.Value("word", nil)
+		}
+		return nil, fmt.Errorf("schema must be an object, got %v", schema)
+	}
+	get := func(k string) any { return s.Values[k] }
+	if _, ok := s.Values["$ref"]; ok {
+		return nil, fmt.Errorf("$ref is not supported; inline the referenced schema")
+	}
+	if c, ok := s.Values["const"]; ok {
+		return c, nil
+	}
+	if enum, ok := get("enum").([]any); ok && len(enum) > 0 {
+		return f.pickAny(enum)
+	}
+	if name, ok := get("x-fake").(string); ok {
+		fields, err := splitArgs(name)
+		if err != nil || len(fields) == 0 {
+			return nil, fmt.Errorf("invalid x-fake %q", name)
+		}
+		return f.Value(fields[0], fields[1:])
+	}
+	switch schemaType(s) {
+	case "object":
+		out := jsonutil.NewOrdered()
+		if props, ok := get("properties").(*jsonutil.Ordered); ok {
+			for _, k := range props.Keys {
+				v, err := f.FromSchema(props.Values[k])
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", k, err)
+				}
+				out.Set(k, v)
+			}
+		}
+		return out, nil
+	case "array":
+		lo := number(get("minItems"), 1)
+		hi := number(get("maxItems"), lo+2)
+		n, err := f.intBetween(lo, hi)
+		if err != nil {
+			return nil, err
+		}
+		items := get("items")
+		if items == nil {
+			items = true
+		}
+		arr := make([]any, 0, n)
+		for i := int64(0); i < n; i++ {
+			v, err := f.FromSchema(items)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+	case "integer":
+		lo := number(get("minimum"), 0)
+		return f.intBetween(lo, number(get("maximum"), lo+1000))
+	case "number":
+		lo := float(get("minimum"), 0)
+		return f.Float(lo, float(get("maximum"), lo+1000))
+	case "boolean":
+		return f.Value("bool", nil)
+	case "null":
+		return nil, nil
+	case "string":
+		return f.schemaString(s)
+	default:
+		return nil, fmt.Errorf("unsupported schema type %q", schemaType(s))
+	}
+}
+
+// schemaType returns the first non-null type, inferring object/array from keywords.
+func schemaType(s *jsonutil.Ordered) string {
+	switch t := s.Values["type"].(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if name, ok := v.(string); ok && name != "null" {
+				return name
+			}
+		}
+		return "null"
+	}
+	if _, ok := s.Values["properties"]; ok {
+		return "object"
+	}
+	if _, ok := s.Values["items"]; ok {
+		return "array"
+	}
+	return "string"
+}
+
+var stringFormats = map[string]string{
+	"uuid":      "uuid",
+	"email":     "email",
+	"uri":       "url",
+	"url":       "url",
+	"ipv4":      "ipv4",
+	"date-time": "date",
+}
+
+func (f *Faker) schemaString(s *jsonutil.Ordered) (any, error) {
+	format, _ := s.Values["format"].(string)
+	switch format {
+	case "date":
+		return f.Value("date", []string{"2006-01-02"})
+	case "time":
+		return f.Value("date", []string{"15:04:05"})
+	case "hostname":
+		w, err := f.Value("word", nil)
+		if err != nil {
+			return nil, err
+		}
+		return w.(string) + ".example.com", nil
+	}
+	if name, ok := stringFormats[format]; ok {
+		return f.Value(name, nil)
+	}
+	minLen := number(s.Values["minLength"], 0)
+	maxLen := number(s.Values["maxLength"], math.MaxInt32)
+	var b strings.Builder
+	for int64(b.Len()) < max(minLen, 1) {
+		w, err := f.Value("word", nil)
+		if err != nil {
+			return nil, err
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(w.(string))
+	}
+	out := b.String()
+	if int64(len(out)) > maxLen {
+		out = out[:maxLen]
+	}
+	return out, nil
+}
+
+func (f *Faker) intBetween(lo, hi int64) (int64, error) {
+	if hi < lo {
+		return 0, fmt.Errorf("maximum %d is below minimum %d", hi, lo)
+	}
+	return randutil.IntRange(f.Rand, lo, hi)
+}
+
+// number reads an integer keyword, falling back to def when it is absent.
+func number(v any, def int64) int64 {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+		if fl, err := n.Float64(); err == nil {
+			return int64(fl)
+		}
+	}
+	return def
+}
+
+// float reads a numeric keyword, falling back to def when it is absent.
+func float(v any, def float64) float64 {
+	if n, ok := v.(json.Number); ok {
+		if fl, err := n.Float64(); err == nil {
+			return fl
+		}
+	}
+	return def
+}
@@ -0,0 +1,140 @@
+package fakeutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Template is text with {{generator args...}} placeholders, e.g.
+// {"id":"{{uuid}}","age":{{int 18 90}},"day":"{{date %Y-%m-%d}}"}.
+// When the template is JSON (it starts with { or [), values are JSON-encoded: escaped
+// inside string literals, and quoted outside them unless they are already JSON.
+type Template struct {
+	literals []string // len(literals) == len(calls)+1
+	calls    []call
+	json     bool
+}
+
+type call struct {
+	name     string
+	args     []string
+	inString bool // inside a JSON string literal
+}
+
+// ParseTemplate compiles s, checking that every placeholder names a known generator.
+func ParseTemplate(s string) (*Template, error) {
+	trimmed := strings.TrimSpace(s)
+	t := &Template{json: strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")}
+	var inString, escaped bool
+	for {
+		start := strings.Index(s, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed placeholder at %q", s[start:])
+		}
+		fields, err := splitArgs(s[start+2 : start+end])
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("empty placeholder")
+		}
+		if _, ok := generators[fields[0]]; !ok {
+			return nil, fmt.Errorf("unknown fake generator %q in template", fields[0])
+		}
+		t.literals = append(t.literals, s[:start])
+		inString, escaped = scanJSONString(s[:start], inString, escaped)
+		t.calls = append(t.calls, call{fields[0], fields[1:], inString})
+		s = s[start+end+2:]
+	}
+	t.literals = append(t.literals, s)
+	return t, nil
+}
+
+// Execute renders one record.
+func (t *Template) Execute(f *Faker) (string, error) {
+	var b strings.Builder
+	for i, c := range t.calls {
+		b.WriteString(t.literals[i])
+		v, err := f.Value(c.name, c.args)
+		if err != nil {
+			return "", err
+		}
+		if !t.json {
+			fmt.Fprint(&b, v)
+			continue
+		}
+		if err := writeJSONValue(&b, v, c.inString); err != nil {
+			return "", err
+		}
+	}
+	b.WriteString(t.literals[len(t.literals)-1])
+	return b.String(), nil
+}
+
+// writeJSONValue writes v so the surrounding JSON stays valid: string contents are
+// escaped inside a string literal, and anything that is not already a JSON value is
+// quoted outside one.
+func writeJSONValue(b *strings.Builder, v any, inString bool) error {
+	text := fmt.Sprint(v)
+	if !inString && json.Valid([]byte(text)) {
+		b.WriteString(text)
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(text); err != nil {
+		return err
+	}
+	quoted := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	if inString {
+		quoted = quoted[1 : len(quoted)-1]
+	}
+	b.Write(quoted)
+	return nil
+}
+
+// scanJSONString reports whether a JSON string literal is still open after s, given
+// the state before it.
+func scanJSONString(s string, inString, escaped bool) (bool, bool) {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && s[i] == '\\':
+			escaped = true
+		case s[i] == '"':
+			inString = !inString
+		}
+	}
+	return inString, escaped
+}
+
+// splitArgs splits on spaces, honouring Go-style double-quoted arguments.
+func splitArgs(s string) ([]string, error) {
+	var out []string
+	s = strings.TrimSpace(s)
+	for s != "" {
+		if s[0] == '"' {
+			q, err := strconv.QuotedPrefix(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted argument in %q", s)
+			}
+			arg, _ := strconv.Unquote(q)
+			out = append(out, arg)
+			s = strings.TrimSpace(s[len(q):])
+			continue
+		}
+		field, rest, _ := strings.Cut(s, " ")
+		out = append(out, field)
+		s = strings.TrimSpace(rest)
+	}
+	return out, nil
+}
@@ -0,0 +1,12 @@
+package hashutil
+
+import (
+	"hash"
+
+	"lukechampine.com/blake3"
+)
+
+// NewBLAKE3 returns a BLAKE3 hash producing size bytes of output (32 is standard).
+func NewBLAKE3(size int) hash.Hash {
+	return blake3.New(size, nil)
+}
@@ -0,0 +1,72 @@
+package hashutil
+
+import (
+	"encoding/hex"
+	"hash"
+	"testing"
+)
+
+// pattern is the input used by the BLAKE3 test vectors: byte i is i % 251.
+func pattern(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i % 251)
+	}
+	return b
+}
+
+// sumChunked writes b in 7-byte pieces to exercise the partial-block buffering.
+func sumChunked(h hash.Hash, b []byte) string {
+	for len(b) > 0 {
+		n := min(len(b), 7)
+		h.Write(b[:n])
+		b = b[n:]
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestVectors(t *testing.T) {
+	cases := []struct {
+		n                                         int
+		blake3, xxh, xxh42, mm32, mm32s7, mm128s7 string
+	}{
+		{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262", "ef46db3751d8e999", "98b1582b0977e704", "00000000", "18c9aec4", "f402c55ac5dec98f2de586f681711c02"},
+		{3, "e1be4d7a8ab5560aa4199eea339849ba8e293d55ca0a81006726d184519e647f", "e5c7bb4533bc65dd", "53897efcb08e44c3", "51d4d0d7", "6976f5c8", "72ca291a6a7779181f31ec190045b159"},
+		{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444", "cfd73aedd2d6a39d", "2465efe5b68877bb", "a8135895", "d882d746", "7cddaee6461fc41c4a323255ca31ec12"},
+		{4097, "9b4052b38f1c5fc8b1f9ff7ac7b27cd242487b3d890d15c96a1c25b8aa0fb995", "ba236f554636de5b", "62c65512d95e9797", "5acac3f7", "debae645", "45b50195cb447b064483d3e0502056c8"},
+		{102400, "bc3e3d41a1146b069abffad3c0d44860cf664390afce4d9661f7902e7943e085", "eb1adcdd9e1369a6", "d11ae86d120a557d", "c9ecbe70", "387a1023", "55e994686b23b30eea0dbdd635a56186"},
+	}
+	for _, c := range cases {
+		b := pattern(c.n)
+		checks := []struct {
+			name string
+			h    hash.Hash
+			want string
+		}{
+			{"blake3", NewBLAKE3(32), c.blake3},
+			{"xxh64", NewXXH64(0), c.xxh},
+			{"xxh64 seed 42", NewXXH64(42), c.xxh42},
+			{"murmur3-32", NewMurmur3_32(0), c.mm32},
+			{"murmur3-32 seed 7", NewMurmur3_32(7), c.mm32s7},
+			{"murmur3-128 seed 7", NewMurmur3_128(7), c.mm128s7},
+		}
+		for _, ch := range checks {
+			if got := sumChunked(ch.h, b); got != ch.want {
+				t.Errorf("%s of %d bytes: got %s want %s", ch.name, c.n, got, ch.want)
+			}
+			ch.h.Reset()
+			ch.h.Write(b)
+			if got := hex.EncodeToString(ch.h.Sum(nil)); got != ch.want {
+				t.Errorf("%s of %d bytes after Reset: got %s", ch.name, c.n, got)
+			}
+		}
+	}
+}
+
+func TestBLAKE3ExtendedOutput(t *testing.T) {
+	// the first 32 bytes of any longer output equal the default digest
+	long := sumChunked(NewBLAKE3(131), []byte("abc"))
+	if len(long) != 262 || long[:64] != "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85" {
+		t.Fatalf("unexpected extended output %s", long)
+	}
+}
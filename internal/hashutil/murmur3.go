@@ -0,0 +1,18 @@
+package hashutil
+
+import (
+	"hash"
+
+	"github.com/spaolacci/murmur3"
+)
+
+// NewMurmur3_32 returns a MurmurHash3 x86_32 hash; Sum is the big-endian digest.
+func NewMurmur3_32(seed uint32) hash.Hash32 {
+	return murmur3.New32WithSeed(seed)
+}
+
+// NewMurmur3_128 returns a MurmurHash3 x64_128 hash. Sum is h1 then h2, each
+// big-endian, matching the common Go implementations.
+func NewMurmur3_128(seed uint32) hash.Hash {
+	return murmur3.New128WithSeed(seed)
+}
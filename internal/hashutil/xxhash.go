@@ -0,0 +1,20 @@
+package hashutil
+
+import (
+	"hash"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// xxh64 keeps its seed across Reset, which xxhash.Digest.Reset drops.
+type xxh64 struct {
+	*xxhash.Digest
+	seed uint64
+}
+
+func (d xxh64) Reset() { d.ResetWithSeed(d.seed) }
+
+// NewXXH64 returns an XXH64 hash with the given seed; Sum is the big-endian digest.
+func NewXXH64(seed uint64) hash.Hash64 {
+	return xxh64{xxhash.NewWithSeed(seed), seed}
+}
@@ -0,0 +1,250 @@
+package idutil
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// GenerateTypes lists the ID kinds Generator and Snowflake can create.
+var GenerateTypes = []string{"ulid", "nanoid", "ksuid", "cuid2", "snowflake", "objectid"}
+
+// NanoIDAlphabets holds named alphabets for NanoID; "url" is the reference default.
+var NanoIDAlphabets = map[string]string{
+	"url":     "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict",
+	"alnum":   "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+	"lower":   "0123456789abcdefghijklmnopqrstuvwxyz",
+	"hex":     "0123456789abcdef",
+	"numbers": "0123456789",
+}
+
+// Generator creates ULIDs, NanoIDs, KSUIDs, CUID2s and ObjectIDs from a random
+// source and clock. ULIDs are monotonic within a millisecond per Generator.
+type Generator struct {
+	Rand io.Reader
+	Now  func() time.Time
+
+	mu          sync.Mutex
+	ulidMs      int64
+	ulidRand    *big.Int
+	cuidCount   uint64
+	cuidPrint   string
+	oidProcess  []byte
+	oidCounter  uint32
+	oidHasState bool
+}
+
+// NewGenerator returns a Generator reading crypto/rand and the system clock.
+func NewGenerator() *Generator {
+	return &Generator{Rand: rand.Reader, Now: time.Now}
+}
+
+// ULID returns a 26-character ULID. Within the same millisecond the random part is
+// incremented instead of redrawn, so IDs from one Generator sort in creation order.
+func (g *Generator) ULID() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ms := g.Now().UnixMilli()
+	if ms <= g.ulidMs && g.ulidRand != nil {
+		ms = g.ulidMs
+		g.ulidRand.Add(g.ulidRand, big.NewInt(1))
+		if g.ulidRand.BitLen() > 80 {
+			return "", errors.New("ULID random component overflowed within one millisecond")
+		}
+	} else {
+		b := make([]byte, 10)
+		if _, err := io.ReadFull(g.Rand, b); err != nil {
+			return "", err
+		}
+		g.ulidRand = new(big.Int).SetBytes(b)
+	}
+	g.ulidMs = ms
+	n := new(big.Int).Lsh(big.NewInt(ms), 80)
+	n.Or(n, g.ulidRand)
+	return encodeBase(n, crockford, 26), nil
+}
+
+// NanoID returns a size-character ID drawn uniformly from alphabet (2-256 symbols).
+func (g *Generator) NanoID(alphabet string, size int) (string, error) {
+	if !utf8.ValidString(alphabet) {
+		return "", errors.New("NanoID alphabet is not valid UTF-8")
+	}
+	symbols := []rune(alphabet)
+	if len(symbols) < 2 || len(symbols) > 256 {
+		return "", fmt.Errorf("NanoID alphabet must have 2-256 characters, got %d", len(symbols))
+	}
+	if size <= 0 {
+		return "", fmt.Errorf("NanoID size must be positive, got %d", size)
+	}
+	// mask is the smallest 2^n-1 covering the alphabet; values above it are rejected
+	mask := 1
+	for mask < len(symbols)-1 {
+		mask = mask<<1 | 1
+	}
+	out := make([]rune, 0, size)
+	buf := make([]byte, size)
+	for len(out) < size {
+		if _, err := io.ReadFull(g.Rand, buf); err != nil {
+			return "", err
+		}
+		for _, b := range buf {
+			if i := int(b) & mask; i < len(symbols) && len(out) < size {
+				out = append(out, symbols[i])
+			}
+		}
+	}
+	return string(out), nil
+}
+
+// KSUID returns a 27-character KSUID: seconds since KSUIDEpoch plus 128 random bits.
+func (g *Generator) KSUID() (string, error) {
+	b := make([]byte, 20)
+	ts := g.Now().Unix() - KSUIDEpoch
+	if ts < 0 || ts > 0xffffffff {
+		return "", errors.New("time is outside the KSUID range")
+	}
+	b[0], b[1], b[2], b[3] = byte(ts>>24), byte(ts>>16), byte(ts>>8), byte(ts)
+	if _, err := io.ReadFull(g.Rand, b[4:]); err != nil {
+		return "", err
+	}
+	return encodeBase(new(big.Int).SetBytes(b), base62, 27), nil
+}
+
+// CUID2 returns a collision-resistant ID of the given length (2-32) following the
+// reference algorithm: a random letter, then a base36 SHA3-512 hash of the time,
+// fresh entropy, a per-Generator counter and a random fingerprint.
+func (g *Generator) CUID2(length int) (string, error) {
+	if length < 2 || length > 32 {
+		return "", fmt.Errorf("CUID2 length must be 2-32, got %d", length)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cuidPrint == "" {
+		seed, err := g.randomBase36(32)
+		if err != nil {
+			return "", err
+		}
+		g.cuidPrint = cuidHash(seed)
+		b := make([]byte, 4)
+		if _, err := io.ReadFull(g.Rand, b); err != nil {
+			return "", err
+		}
+		g.cuidCount = uint64(b[0])<<24 | uint64(b[1])<<16 | uint64(b[2])<<8 | uint64(b[3])
+	}
+	g.cuidCount++
+	salt, err := g.randomBase36(length)
+	if err != nil {
+		return "", err
+	}
+	first, err := g.NanoID("abcdefghijklmnopqrstuvwxyz", 1)
+	if err != nil {
+		return "", err
+	}
+	input := strconv.FormatInt(g.Now().UnixMilli(), 36) + salt + strconv.FormatUint(g.cuidCount, 36) + g.cuidPrint
+	return first + cuidHash(input)[1:length], nil
+}
+
+func (g *Generator) randomBase36(n int) (string, error) {
+	return g.NanoID(NanoIDAlphabets["lower"], n)
+}
+
+// cuidHash is the base36 SHA3-512 digest with its first (biased) digit dropped.
+func cuidHash(s string) string {
+	sum := sha3.Sum512([]byte(s))
+	return new(big.Int).SetBytes(sum[:]).Text(36)[1:]
+}
+
+// ObjectID returns a 24-hex-digit MongoDB ObjectID: seconds, a per-Generator random
+// value and an incrementing counter that starts at a random offset.
+func (g *Generator) ObjectID() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.oidHasState {
+		b := make([]byte, 8)
+		if _, err := io.ReadFull(g.Rand, b); err != nil {
+			return "", err
+		}
+		g.oidProcess = b[:5]
+		g.oidCounter = uint32(b[5])<<16 | uint32(b[6])<<8 | uint32(b[7])
+		g.oidHasState = true
+	}
+	g.oidCounter = (g.oidCounter + 1) & 0xffffff
+	ts := uint32(g.Now().Unix())
+	b := []byte{byte(ts >> 24), byte(ts >> 16), byte(ts >> 8), byte(ts)}
+	b = append(b, g.oidProcess...)
+	b = append(b, byte(g.oidCounter>>16), byte(g.oidCounter>>8), byte(g.oidCounter))
+	return hex.EncodeToString(b), nil
+}
+
+// Snowflake generates 63-bit Snowflake IDs: timestamp | worker | sequence, with
+// configurable widths. When the sequence runs out within a millisecond the
+// timestamp borrows the next millisecond instead of waiting for the clock.
+type Snowflake struct {
+	Epoch        time.Time
+	WorkerBits   uint
+	SequenceBits uint
+	Worker       uint64
+	Now          func() time.Time
+
+	mu     sync.Mutex
+	lastMs int64
+	seq    uint64
+}
+
+// NewSnowflake returns a generator with the Twitter layout (10 worker, 12 sequence bits).
+func NewSnowflake(epoch time.Time, worker uint64) *Snowflake {
+	return &Snowflake{Epoch: epoch, WorkerBits: 10, SequenceBits: 12, Worker: worker, Now: time.Now}
+}
+
+// Next returns the next ID.
+func (s *Snowflake) Next() (uint64, error) {
+	if s.WorkerBits+s.SequenceBits > 22 {
+		return 0, fmt.Errorf("worker bits + sequence bits must be at most 22, got %d", s.WorkerBits+s.SequenceBits)
+	}
+	if s.Worker >= 1<<s.WorkerBits {
+		return 0, fmt.Errorf("worker %d does not fit in %d bits", s.Worker, s.WorkerBits)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ms := s.Now().Sub(s.Epoch).Milliseconds()
+	if ms < 0 {
+		return 0, errors.New("current time is before the Snowflake epoch")
+	}
+	if ms <= s.lastMs {
+		ms = s.lastMs
+		s.seq++
+		if s.seq >= 1<<s.SequenceBits {
+			ms++
+			s.seq = 0
+		}
+	} else {
+		s.seq = 0
+	}
+	s.lastMs = ms
+	timeBits := 63 - s.WorkerBits - s.SequenceBits
+	if ms >= 1<<timeBits {
+		return 0, fmt.Errorf("timestamp overflows %d bits; choose a later epoch", timeBits)
+	}
+	return uint64(ms)<<(s.WorkerBits+s.SequenceBits) | s.Worker<<s.SequenceBits | s.seq, nil
+}
+
+// encodeBase renders n in alphabet, left-padded with the zero symbol to width.
+func encodeBase(n *big.Int, alphabet string, width int) string {
+	base, mod := big.NewInt(int64(len(alphabet))), new(big.Int)
+	v := new(big.Int).Set(n)
+	out := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		v.DivMod(v, base, mod)
+		out[i] = alphabet[mod.Int64()]
+	}
+	return string(out)
+}
@@ -0,0 +1,209 @@
+package idutil
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"dt/internal/uuidutil"
+)
+
+// Types lists the ID kinds Inspect understands.
+var Types = []string{"auto", "uuid", "ulid", "ksuid", "objectid", "snowflake"}
+
+// SnowflakeEpochs holds well-known Snowflake epochs in Unix milliseconds.
+var SnowflakeEpochs = map[string]int64{
+	"twitter": 1288834974657,
+	"discord": 1420070400000,
+}
+
+// KSUIDEpoch is the KSUID timestamp origin in Unix seconds (2014-05-13T16:53:20Z).
+const KSUIDEpoch = 1400000000
+
+const (
+	crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base62    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// Field is a decoded component of an ID, kept in display order.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Info describes a decoded ID.
+type Info struct {
+	Type    string
+	Time    time.Time
+	HasTime bool
+	Fields  []Field
+}
+
+// Inspect decodes id as the given type ("auto" or "" to detect it).
+// snowflakeEpoch is the origin used for Snowflake IDs.
+func Inspect(id string, typ string, snowflakeEpoch time.Time) (Info, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return Info{}, errors.New("empty ID")
+	}
+	if typ == "" || typ == "auto" {
+		typ = Detect(id)
+		if typ == "" {
+			return Info{}, fmt.Errorf("unrecognized ID %q (use --type)", id)
+		}
+	}
+	switch typ {
+	case "uuid":
+		return DecodeUUID(id)
+	case "ulid":
+		return DecodeULID(id)
+	case "ksuid":
+		return DecodeKSUID(id)
+	case "objectid":
+		return DecodeObjectID(id)
+	case "snowflake":
+		return DecodeSnowflake(id, snowflakeEpoch)
+	default:
+		return Info{}, fmt.Errorf("unsupported ID type %q (use %s)", typ, strings.Join(Types, "|"))
+	}
+}
+
+// Detect guesses the ID type from its length and alphabet; it returns "" if nothing fits.
+func Detect(id string) string {
+	if _, err := uuidutil.Parse(id); err == nil {
+		return "uuid"
+	}
+	switch {
+	case len(id) == 24 && isHex(id):
+		return "objectid"
+	case len(id) == 26 && inAlphabet(strings.ToUpper(id), crockford) && id[0] <= '7':
+		return "ulid"
+	case len(id) == 27 && inAlphabet(id, base62):
+		return "ksuid"
+	case len(id) <= 20 && inAlphabet(id, "0123456789"):
+		return "snowflake"
+	}
+	return ""
+}
+
+// DecodeUUID reports the version, variant and, for v1/v6/v7, the embedded timestamp.
+func DecodeUUID(id string) (Info, error) {
+	u, err := uuidutil.Parse(id)
+	if err != nil {
+		return Info{}, err
+	}
+	info := Info{Type: "uuid"}
+	info.Fields = append(info.Fields, Field{"version", strconv.Itoa(u.Version())}, Field{"variant", u.Variant()})
+	if t, err := u.Time(); err == nil {
+		info.Time, info.HasTime = t, true
+	}
+	switch u.Version() {
+	case 1, 6:
+		info.Fields = append(info.Fields, Field{"clock_seq", strconv.Itoa(u.ClockSeq())}, Field{"node", u.Node()})
+	case 7:
+		info.Fields = append(info.Fields, Field{"random", hex.EncodeToString(u[6:])})
+	}
+	return info, nil
+}
+
+// DecodeULID splits a ULID into its 48-bit millisecond timestamp and 80-bit randomness.
+func DecodeULID(id string) (Info, error) {
+	s := strings.ToUpper(id)
+	if len(s) != 26 || !inAlphabet(s, crockford) {
+		return Info{}, fmt.Errorf("invalid ULID %q: expected 26 Crockford base32 characters", id)
+	}
+	if s[0] > '7' {
+		return Info{}, fmt.Errorf("invalid ULID %q: timestamp overflows 48 bits", id)
+	}
+	var ms int64
+	for i := 0; i < 10; i++ {
+		ms = ms<<5 | int64(strings.IndexByte(crockford, s[i]))
+	}
+	r := new(big.Int)
+	for i := 10; i < 26; i++ {
+		r.Lsh(r, 5).Or(r, big.NewInt(int64(strings.IndexByte(crockford, s[i]))))
+	}
+	return Info{
+		Type:    "ulid",
+		Time:    time.UnixMilli(ms),
+		HasTime: true,
+		Fields:  []Field{{"random", hex.EncodeToString(r.FillBytes(make([]byte, 10)))}},
+	}, nil
+}
+
+// DecodeKSUID splits a KSUID into its 32-bit second timestamp and 128-bit payload.
+func DecodeKSUID(id string) (Info, error) {
+	if len(id) != 27 || !inAlphabet(id, base62) {
+		return Info{}, fmt.Errorf("invalid KSUID %q: expected 27 base62 characters", id)
+	}
+	n := new(big.Int)
+	for i := 0; i < len(id); i++ {
+		n.Mul(n, big.NewInt(62)).Add(n, big.NewInt(int64(strings.IndexByte(base62, id[i]))))
+	}
+	if n.BitLen() > 160 {
+		return Info{}, fmt.Errorf("invalid KSUID %q: value exceeds 160 bits", id)
+	}
+	b := n.FillBytes(make([]byte, 20))
+	ts := int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+	return Info{
+		Type:    "ksuid",
+		Time:    time.Unix(ts+KSUIDEpoch, 0),
+		HasTime: true,
+		Fields:  []Field{{"payload", hex.EncodeToString(b[4:])}},
+	}, nil
+}
+
+// DecodeObjectID splits a MongoDB ObjectID into seconds, the per-process random value and counter.
+func DecodeObjectID(id string) (Info, error) {
+	if len(id) != 24 || !isHex(id) {
+		return Info{}, fmt.Errorf("invalid ObjectID %q: expected 24 hex digits", id)
+	}
+	b, _ := hex.DecodeString(id)
+	ts := int64(b[0])<<24 | int64(b[1])<<16 | int64(b[2])<<8 | int64(b[3])
+	counter := int(b[9])<<16 | int(b[10])<<8 | int(b[11])
+	return Info{
+		Type:    "objectid",
+		Time:    time.Unix(ts, 0),
+		HasTime: true,
+		Fields:  []Field{{"random", hex.EncodeToString(b[4:9])}, {"counter", strconv.Itoa(counter)}},
+	}, nil
+}
+
+// DecodeSnowflake splits a Twitter-layout Snowflake: 41-bit milliseconds since epoch,
+// 10-bit worker (5-bit datacenter + 5-bit worker on Twitter) and 12-bit sequence.
+func DecodeSnowflake(id string, epoch time.Time) (Info, error) {
+	n, err := strconv.ParseUint(id, 10, 64)
+	if err != nil || n>>63 != 0 {
+		return Info{}, fmt.Errorf("invalid Snowflake %q: expected a positive 63-bit integer", id)
+	}
+	ms := int64(n >> 22)
+	worker := (n >> 12) & 0x3ff
+	return Info{
+		Type:    "snowflake",
+		Time:    epoch.Add(time.Duration(ms) * time.Millisecond),
+		HasTime: true,
+		Fields: []Field{
+			{"worker", strconv.FormatUint(worker, 10)},
+			{"datacenter", strconv.FormatUint(worker>>5, 10)},
+			{"process", strconv.FormatUint(worker&0x1f, 10)},
+			{"sequence", strconv.FormatUint(n&0xfff, 10)},
+		},
+	}, nil
+}
+
+func isHex(s string) bool {
+	return inAlphabet(strings.ToLower(s), "0123456789abcdef")
+}
+
+func inAlphabet(s, alphabet string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(alphabet, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
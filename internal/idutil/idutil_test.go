@@ -0,0 +1,133 @@
+package idutil
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestInspect(t *testing.T) {
+	discord := time.UnixMilli(SnowflakeEpochs["discord"])
+	cases := []struct {
+		id, typ string
+		want    time.Time
+		field   Field
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "ulid", time.UnixMilli(1469922850259), Field{"random", "d6764c61efb99302bd5b"}},
+		{"0ujtsYcgvSTl8PAuAdqWYSMnLOv", "ksuid", time.Unix(1507608047, 0), Field{"payload", "b5a1cd34b5f99d1154fb6853345c9735"}},
+		{"507f1f77bcf86cd799439011", "objectid", time.Unix(1350508407, 0), Field{"counter", "4427793"}},
+		{"017F22E2-79B0-7CC3-98C4-DC0C0C07398F", "uuid", time.UnixMilli(1645557742000), Field{"version", "7"}},
+		{"175928847299117063", "snowflake", time.UnixMilli(1462015105796), Field{"sequence", "7"}},
+	}
+	for _, c := range cases {
+		if got := Detect(c.id); got != c.typ {
+			t.Fatalf("%s: detected %q want %q", c.id, got, c.typ)
+		}
+		info, err := Inspect(c.id, "auto", discord)
+		if err != nil {
+			t.Fatalf("%s: %v", c.id, err)
+		}
+		if !info.HasTime || !info.Time.Equal(c.want) {
+			t.Fatalf("%s: got time %s want %s", c.id, info.Time, c.want)
+		}
+		found := false
+		for _, f := range info.Fields {
+			found = found || f == c.field
+		}
+		if !found {
+			t.Fatalf("%s: missing field %+v in %+v", c.id, c.field, info.Fields)
+		}
+	}
+	if _, err := Inspect("not-an-id", "auto", discord); err == nil {
+		t.Fatalf("expected error for unknown ID")
+	}
+	if _, err := Inspect("8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "ulid", discord); err == nil {
+		t.Fatalf("expected overflow error")
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	now := time.UnixMilli(1700000000123)
+	g := &Generator{Rand: rand.New(rand.NewSource(1)), Now: func() time.Time { return now }}
+	var prev string
+	for i := 0; i < 3; i++ {
+		id, err := g.ULID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := DecodeULID(id)
+		if err != nil || !info.Time.Equal(now) {
+			t.Fatalf("ULID %s: time %s err %v", id, info.Time, err)
+		}
+		if id <= prev {
+			t.Fatalf("ULIDs not monotonic: %s after %s", id, prev)
+		}
+		prev = id
+	}
+	id, err := g.NanoID("abc", 30)
+	if err != nil || len(id) != 30 || strings.Trim(id, "abc") != "" {
+		t.Fatalf("NanoID %q err %v", id, err)
+	}
+	if _, err := g.NanoID("a", 5); err == nil {
+		t.Fatal("expected error for one-character alphabet")
+	}
+	id, err = g.NanoID("αβ", 20)
+	if err != nil || utf8.RuneCountInString(id) != 20 || strings.Trim(id, "αβ") != "" {
+		t.Fatalf("NanoID with a non-ASCII alphabet gave %q err %v", id, err)
+	}
+	if _, err := g.NanoID("é", 5); err == nil {
+		t.Fatal("a one-rune alphabet must be rejected even though it is two bytes")
+	}
+	id, err = g.KSUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := DecodeKSUID(id); err != nil || !info.Time.Equal(now.Truncate(time.Second)) {
+		t.Fatalf("KSUID %s: time %s err %v", id, info.Time, err)
+	}
+	id, err = g.ObjectID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info, err := DecodeObjectID(id); err != nil || !info.Time.Equal(now.Truncate(time.Second)) {
+		t.Fatalf("ObjectID %s: time %s err %v", id, info.Time, err)
+	}
+	id, err = g.CUID2(10)
+	if err != nil || len(id) != 10 || id[0] < 'a' || id[0] > 'z' {
+		t.Fatalf("CUID2 %q err %v", id, err)
+	}
+}
+
+func TestSnowflake(t *testing.T) {
+	epoch := time.UnixMilli(SnowflakeEpochs["discord"])
+	now := epoch.Add(time.Hour)
+	s := NewSnowflake(epoch, 5)
+	s.Now = func() time.Time { return now }
+	s.SequenceBits = 1
+	var ids []uint64
+	for i := 0; i < 3; i++ {
+		n, err := s.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, n)
+	}
+	// with one sequence bit the third ID borrows the next millisecond
+	if ids[0]&1 != 0 || ids[1]&1 != 1 || ids[2]>>11 != ids[0]>>11+1 {
+		t.Fatalf("unexpected sequence layout: %b", ids)
+	}
+	s = NewSnowflake(epoch, 5)
+	s.Now = func() time.Time { return now }
+	n, _ := s.Next()
+	info, err := DecodeSnowflake(fmt.Sprint(n), epoch)
+	if err != nil || !info.Time.Equal(now) {
+		t.Fatalf("snowflake %d: time %s err %v", n, info.Time, err)
+	}
+	s.Worker = 1 << 10
+	if _, err := s.Next(); err == nil {
+		t.Fatal("expected error for worker overflowing its bits")
+	}
+}
@@ -65,3 +65,29 @@ func TestStringify(t *testing.T) {
         t.Fatalf("did not expect quotes: %q", string(got2))
     }
 }
+
+func TestDecodeOrdered(t *testing.T) {
+    v, err := DecodeOrdered(strings.NewReader(`{"b":1,"a":{"y":[true,null],"x":"<&>"}}`))
+    if err != nil {
+        t.Fatal(err)
+    }
+    o := v.(*Ordered)
+    if strings.Join(o.Keys, ",") != "b,a" {
+        t.Fatalf("keys out of order: %v", o.Keys)
+    }
+    b, err := o.MarshalJSON()
+    if err != nil || string(b) != `{"b":1,"a":{"y":[true,null],"x":"<&>"}}` {
+        t.Fatalf("round trip: %s %v", b, err)
+    }
+    if _, err := DecodeOrdered(strings.NewReader(`{"a":`)); err == nil {
+        t.Fatal("expected error for truncated input")
+    }
+    for _, in := range []string{`{"a":1} {"admin":true}`, `{"a":1}}`, `[1] x`} {
+        if _, err := DecodeOrdered(strings.NewReader(in)); err == nil {
+            t.Fatalf("expected error for trailing data in %s", in)
+        }
+    }
+    if _, err := DecodeOrdered(strings.NewReader("{\"a\":1}\n\n")); err != nil {
+        t.Fatalf("trailing whitespace should be accepted: %v", err)
+    }
+}
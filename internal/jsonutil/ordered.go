@@ -0,0 +1,124 @@
+package jsonutil
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+)
+
+// Ordered is a JSON object that remembers the order of its keys.
+type Ordered struct {
+    Keys   []string
+    Values map[string]any
+}
+
+// NewOrdered returns an empty Ordered.
+func NewOrdered() *Ordered {
+    return &Ordered{Values: map[string]any{}}
+}
+
+// Set adds or replaces k, appending new keys at the end.
+func (o *Ordered) Set(k string, v any) {
+    if _, ok := o.Values[k]; !ok {
+        o.Keys = append(o.Keys, k)
+    }
+    o.Values[k] = v
+}
+
+// SetAfter adds or replaces k; a new key is placed right after the key after,
+// or at the end when after is absent.
+func (o *Ordered) SetAfter(after, k string, v any) {
+    if _, ok := o.Values[k]; !ok {
+        i := len(o.Keys)
+        for j, key := range o.Keys {
+            if key == after {
+                i = j + 1
+                break
+            }
+        }
+        o.Keys = append(o.Keys[:i], append([]string{k}, o.Keys[i:]...)...)
+    }
+    o.Values[k] = v
+}
+
+// MarshalJSON writes the keys in insertion order without HTML escaping.
+func (o *Ordered) MarshalJSON() ([]byte, error) {
+    var buf bytes.Buffer
+    enc := json.NewEncoder(&buf)
+    enc.SetEscapeHTML(false)
+    buf.WriteByte('{')
+    for i, k := range o.Keys {
+        if i > 0 {
+            buf.WriteByte(',')
+        }
+        if err := enc.Encode(k); err != nil {
+            return nil, err
+        }
+        buf.Truncate(buf.Len() - 1) // Encode appends a newline
+        buf.WriteByte(':')
+        if err := enc.Encode(o.Values[k]); err != nil {
+            return nil, err
+        }
+        buf.Truncate(buf.Len() - 1)
+    }
+    buf.WriteByte('}')
+    return buf.Bytes(), nil
+}
+
+// DecodeOrdered decodes the single JSON value in r, returning objects as *Ordered
+// and numbers as json.Number. Anything but whitespace after the value is an error.
+func DecodeOrdered(r io.Reader) (any, error) {
+    dec := json.NewDecoder(r)
+    dec.UseNumber()
+    v, err := decodeValue(dec)
+    if err != nil {
+        return nil, err
+    }
+    if _, err := dec.Token(); err != io.EOF {
+        if err == nil {
+            err = errors.New("unexpected data after the JSON value")
+        }
+        return nil, err
+    }
+    return v, nil
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+    tok, err := dec.Token()
+    if err != nil {
+        return nil, err
+    }
+    switch tok {
+    case json.Delim('{'):
+        o := NewOrdered()
+        for dec.More() {
+            kt, err := dec.Token()
+            if err != nil {
+                return nil, err
+            }
+            v, err := decodeValue(dec)
+            if err != nil {
+                return nil, err
+            }
+            o.Set(kt.(string), v)
+        }
+        _, err := dec.Token()
+        return o, err
+    case json.Delim('['):
+        arr := []any{}
+        for dec.More() {
+            v, err := decodeValue(dec)
+            if err != nil {
+                return nil, err
+            }
+            arr = append(arr, v)
+        }
+        _, err := dec.Token()
+        return arr, err
+    case json.Delim('}'), json.Delim(']'):
+        return nil, fmt.Errorf("unexpected %v", tok)
+    }
+    return tok, nil
+}
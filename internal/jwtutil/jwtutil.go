@@ -0,0 +1,290 @@
+package jwtutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers crypto.SHA256 for the RS/PS/ES algorithms
+	_ "crypto/sha512" // registers crypto.SHA384 and crypto.SHA512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Algorithms lists the JWS algorithms Sign and Verify support. "none" is never accepted.
+var Algorithms = []string{
+	"HS256", "HS384", "HS512",
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+var b64 = base64.RawURLEncoding
+
+// Token is a decoded JWS in compact serialization.
+type Token struct {
+	Header       []byte // decoded JSON
+	Payload      []byte // decoded JSON (or the raw payload if it is not JSON)
+	Signature    []byte
+	SigningInput string // the first two segments exactly as received
+	Alg          string
+	Kid          string
+}
+
+// Parse splits and decodes a compact JWS, tolerating a leading "Bearer ". The
+// signature is not checked.
+func Parse(s string) (*Token, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > 7 && strings.EqualFold(s[:7], "bearer ") {
+		s = strings.TrimSpace(s[7:])
+	}
+	parts := strings.Split(s, ".")
+	switch len(parts) {
+	case 3:
+	case 5:
+		return nil, errors.New("encrypted JWTs (JWE, five segments) are not supported")
+	default:
+		return nil, fmt.Errorf("a JWT has three dot-separated segments, got %d", len(parts))
+	}
+	t := &Token{SigningInput: parts[0] + "." + parts[1]}
+	var err error
+	if t.Header, err = decodeSegment(parts[0]); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	if t.Payload, err = decodeSegment(parts[1]); err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+	if t.Signature, err = decodeSegment(parts[2]); err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	var h struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(t.Header, &h); err != nil {
+		return nil, fmt.Errorf("header is not a JSON object: %w", err)
+	}
+	t.Alg, t.Kid = h.Alg, h.Kid
+	return t, nil
+}
+
+// decodeSegment accepts unpadded base64url and, leniently, padded input.
+func decodeSegment(s string) ([]byte, error) {
+	return b64.DecodeString(strings.TrimRight(s, "="))
+}
+
+// Sign returns the compact JWS for the given header and payload JSON.
+func Sign(alg string, key any, header, payload []byte) (string, error) {
+	input := b64.EncodeToString(header) + "." + b64.EncodeToString(payload)
+	sig, err := signBytes(alg, key, []byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + b64.EncodeToString(sig), nil
+}
+
+// Verify checks t's signature with key, which must suit t.Alg; private keys are
+// reduced to their public half.
+func (t *Token) Verify(key any) error {
+	if strings.EqualFold(t.Alg, "none") || t.Alg == "" {
+		return errors.New(`unsigned token (alg "none") rejected`)
+	}
+	if !Compatible(t.Alg, key) {
+		return fmt.Errorf("key type %s cannot verify %s", KeyType(key), t.Alg)
+	}
+	input := []byte(t.SigningInput)
+	switch t.Alg[:2] {
+	case "HS":
+		want, err := signBytes(t.Alg, key, input)
+		if err != nil {
+			return err
+		}
+		if !hmac.Equal(want, t.Signature) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	case "RS", "PS":
+		pub := publicKey(key).(*rsa.PublicKey)
+		h, _ := hashFor(t.Alg)
+		digest := sum(h, input)
+		var err error
+		if t.Alg[0] == 'R' {
+			err = rsa.VerifyPKCS1v15(pub, h, digest, t.Signature)
+		} else {
+			err = rsa.VerifyPSS(pub, h, digest, t.Signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+		}
+		if err != nil {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	case "ES":
+		pub := publicKey(key).(*ecdsa.PublicKey)
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(t.Signature) != 2*size {
+			return fmt.Errorf("%s signatures are %d bytes, got %d", t.Alg, 2*size, len(t.Signature))
+		}
+		h, _ := hashFor(t.Alg)
+		r := new(big.Int).SetBytes(t.Signature[:size])
+		s := new(big.Int).SetBytes(t.Signature[size:])
+		if !ecdsa.Verify(pub, sum(h, input), r, s) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	default: // EdDSA
+		if !ed25519.Verify(publicKey(key).(ed25519.PublicKey), input, t.Signature) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+	}
+}
+
+func signBytes(alg string, key any, input []byte) ([]byte, error) {
+	if !Compatible(alg, key) {
+		if _, ok := hashFor(alg); !ok && alg != "EdDSA" {
+			return nil, fmt.Errorf("unsupported algorithm %q (use %s)", alg, strings.Join(Algorithms, "|"))
+		}
+		return nil, fmt.Errorf("key type %s cannot sign %s", KeyType(key), alg)
+	}
+	h, _ := hashFor(alg)
+	switch alg[:2] {
+	case "HS":
+		mac := hmac.New(h.New, key.([]byte))
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case "RS", "PS":
+		priv, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("signing needs a private key")
+		}
+		if alg[0] == 'R' {
+			return rsa.SignPKCS1v15(nil, priv, h, sum(h, input))
+		}
+		return rsa.SignPSS(rand.Reader, priv, h, sum(h, input), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case "ES":
+		priv, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("signing needs a private key")
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, priv, sum(h, input))
+		if err != nil {
+			return nil, err
+		}
+		size := (priv.Curve.Params().BitSize + 7) / 8
+		sig := make([]byte, 2*size)
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+		return sig, nil
+	default: // EdDSA
+		priv, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("signing needs a private key")
+		}
+		return ed25519.Sign(priv, input), nil
+	}
+}
+
+// hashFor returns the digest an algorithm uses, from its 256/384/512 suffix.
+func hashFor(alg string) (crypto.Hash, bool) {
+	if len(alg) != 5 {
+		return 0, false
+	}
+	switch alg[:2] {
+	case "HS", "RS", "PS", "ES":
+	default:
+		return 0, false
+	}
+	switch alg[2:] {
+	case "256":
+		return crypto.SHA256, true
+	case "384":
+		return crypto.SHA384, true
+	case "512":
+		return crypto.SHA512, true
+	}
+	return 0, false
+}
+
+func sum(h crypto.Hash, b []byte) []byte {
+	d := h.New()
+	d.Write(b)
+	return d.Sum(nil)
+}
+
+// Compatible reports whether key can sign or verify alg. ES algorithms are tied to
+// their curve: ES256 to P-256, ES384 to P-384 and ES512 to P-521.
+func Compatible(alg string, key any) bool {
+	if alg == "EdDSA" {
+		_, ok := publicKey(key).(ed25519.PublicKey)
+		return ok
+	}
+	if _, ok := hashFor(alg); !ok {
+		return false
+	}
+	switch pub := publicKey(key).(type) {
+	case []byte:
+		return alg[:2] == "HS"
+	case *rsa.PublicKey:
+		return alg[:2] == "RS" || alg[:2] == "PS"
+	case *ecdsa.PublicKey:
+		return alg[:2] == "ES" && DefaultAlg(pub) == alg
+	}
+	return false
+}
+
+// DefaultAlg picks the usual algorithm for a key: HS256 for secrets, RS256 for RSA,
+// the curve's ES algorithm for ECDSA and EdDSA for Ed25519.
+func DefaultAlg(key any) string {
+	switch pub := publicKey(key).(type) {
+	case []byte:
+		return "HS256"
+	case *rsa.PublicKey:
+		return "RS256"
+	case *ecdsa.PublicKey:
+		switch pub.Curve.Params().BitSize {
+		case 256:
+			return "ES256"
+		case 384:
+			return "ES384"
+		case 521:
+			return "ES512"
+		}
+	case ed25519.PublicKey:
+		return "EdDSA"
+	}
+	return ""
+}
+
+// KeyType names a key for messages: "secret", "RSA-2048", "EC P-256" or "Ed25519".
+func KeyType(key any) string {
+	switch pub := publicKey(key).(type) {
+	case []byte:
+		return "secret"
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA-%d", pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "EC " + pub.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	}
+	return fmt.Sprintf("%T", key)
+}
+
+// publicKey returns the public half of a private key, and anything else unchanged.
+func publicKey(key any) any {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &k.PublicKey
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey
+	case ed25519.PrivateKey:
+		return k.Public()
+	}
+	return key
+}
@@ -0,0 +1,285 @@
+package jwtutil
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Key is a signing or verification key with the JWK metadata that selects it.
+type Key struct {
+	Kid string
+	Alg string // from the JWK "alg" member, if any
+	Key any    // []byte, *rsa.*, *ecdsa.* or ed25519.*
+}
+
+// LoadKeys reads PEM data (PKCS#8, PKCS#1 or SEC1 private keys, PKIX public keys
+// or certificates) or JSON holding a single JWK or a JWKS {"keys": [...]}.
+func LoadKeys(data []byte) ([]Key, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		return parseJWKs(data)
+	}
+	var keys []Key
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		k, err := parsePEM(block)
+		if err != nil {
+			return nil, err
+		}
+		if k != nil {
+			keys = append(keys, Key{Key: k})
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no PEM key or JWK found")
+	}
+	return keys, nil
+}
+
+func parsePEM(block *pem.Block) (any, error) {
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	case "EC PARAMETERS":
+		return nil, nil // emitted by "openssl ecparam -genkey" ahead of the key
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+// jwk holds the RFC 7517/7518/8037 members dt understands.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	D   string `json:"d"`
+	P   string `json:"p"`
+	Q   string `json:"q"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// errUnsupported marks a JWK that is well-formed but of a kind dt cannot use, such
+// as an X25519 or secp256k1 key; a JWKS skips those rather than failing.
+var errUnsupported = errors.New("unsupported")
+
+func parseJWKs(data []byte) ([]Key, error) {
+	var set struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWK JSON: %w", err)
+	}
+	raws := set.Keys
+	if raws == nil {
+		raws = []json.RawMessage{data}
+	}
+	var keys []Key
+	var skipped error
+	for i, raw := range raws {
+		var j jwk
+		if err := json.Unmarshal(raw, &j); err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		name := fmt.Sprintf("key %d", i)
+		if j.Kid != "" {
+			name = fmt.Sprintf("key %q", j.Kid)
+		}
+		var k any
+		var err error
+		if j.Use == "enc" {
+			err = fmt.Errorf(`%w use "enc": the key is for encryption`, errUnsupported)
+		} else {
+			k, err = j.key()
+		}
+		if errors.Is(err, errUnsupported) {
+			if skipped == nil {
+				skipped = fmt.Errorf("%s: %w", name, err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		keys = append(keys, Key{Kid: j.Kid, Alg: j.Alg, Key: k})
+	}
+	if len(keys) == 0 {
+		if skipped != nil {
+			return nil, fmt.Errorf("no usable key: %w", skipped)
+		}
+		return nil, errors.New("JWKS has no keys")
+	}
+	return keys, nil
+}
+
+func (j jwk) key() (any, error) {
+	var err error
+	num := func(name, s string) *big.Int {
+		if err != nil {
+			return nil
+		}
+		b, e := decodeSegment(s)
+		if e != nil || len(b) == 0 {
+			err = fmt.Errorf("invalid or missing %q", name)
+			return nil
+		}
+		return new(big.Int).SetBytes(b)
+	}
+	switch j.Kty {
+	case "oct":
+		k, e := decodeSegment(j.K)
+		if e != nil || len(k) == 0 {
+			return nil, errors.New(`invalid or missing "k"`)
+		}
+		return k, nil
+	case "RSA":
+		pub := rsa.PublicKey{N: num("n", j.N)}
+		e := num("e", j.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New(`invalid "e"`)
+		}
+		pub.E = int(e.Int64())
+		if j.D == "" {
+			return &pub, nil
+		}
+		priv := &rsa.PrivateKey{PublicKey: pub, D: num("d", j.D), Primes: []*big.Int{num("p", j.P), num("q", j.Q)}}
+		if err != nil {
+			return nil, err
+		}
+		if err := priv.Validate(); err != nil {
+			return nil, err
+		}
+		priv.Precompute()
+		return priv, nil
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, ok := curves[j.Crv]
+		if !ok {
+			return nil, fmt.Errorf("%w EC curve %q", errUnsupported, j.Crv)
+		}
+		pub := ecdsa.PublicKey{Curve: curve, X: num("x", j.X), Y: num("y", j.Y)}
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		if j.D == "" {
+			return &pub, nil
+		}
+		d := num("d", j.D)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkECPrivate(&pub, d); err != nil {
+			return nil, err
+		}
+		return &ecdsa.PrivateKey{PublicKey: pub, D: d}, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w OKP curve %q", errUnsupported, j.Crv)
+		}
+		var x []byte
+		if j.X != "" || j.D == "" {
+			var e error
+			x, e = decodeSegment(j.X)
+			if e != nil || len(x) != ed25519.PublicKeySize {
+				return nil, errors.New(`invalid "x"`)
+			}
+		}
+		if j.D == "" {
+			return ed25519.PublicKey(x), nil
+		}
+		seed, e := decodeSegment(j.D)
+		if e != nil || len(seed) != ed25519.SeedSize {
+			return nil, errors.New(`invalid "d"`)
+		}
+		priv := ed25519.NewKeyFromSeed(seed)
+		if x != nil && !bytes.Equal(priv.Public().(ed25519.PublicKey), x) {
+			return nil, errors.New(`"d" does not match "x"`)
+		}
+		return priv, nil
+	default:
+		return nil, fmt.Errorf("%w key type %q", errUnsupported, j.Kty)
+	}
+}
+
+// checkECPrivate reports whether d is a valid scalar whose public point is pub.
+func checkECPrivate(pub *ecdsa.PublicKey, d *big.Int) error {
+	curves := map[elliptic.Curve]ecdh.Curve{elliptic.P256(): ecdh.P256(), elliptic.P384(): ecdh.P384(), elliptic.P521(): ecdh.P521()}
+	size := (pub.Curve.Params().BitSize + 7) / 8
+	if d.BitLen() > size*8 {
+		return errors.New(`invalid "d"`)
+	}
+	priv, err := curves[pub.Curve].NewPrivateKey(d.FillBytes(make([]byte, size)))
+	if err != nil {
+		return errors.New(`invalid "d"`)
+	}
+	want := make([]byte, 1+2*size)
+	want[0] = 4 // uncompressed point
+	pub.X.FillBytes(want[1 : 1+size])
+	pub.Y.FillBytes(want[1+size:])
+	if !bytes.Equal(priv.PublicKey().Bytes(), want) {
+		return errors.New(`"d" does not match "x" and "y"`)
+	}
+	return nil
+}
+
+// Candidates returns the keys that may verify a token with alg and kid: the key
+// whose kid matches when kid is set and present, otherwise every compatible key.
+func Candidates(keys []Key, alg, kid string) []Key {
+	var out []Key
+	for _, k := range keys {
+		if k.Alg != "" && k.Alg != alg {
+			continue
+		}
+		if Compatible(alg, k.Key) {
+			out = append(out, k)
+		}
+	}
+	if kid == "" {
+		return out
+	}
+	for _, k := range out {
+		if k.Kid == kid {
+			return []Key{k}
+		}
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+package passwdutil
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptB64 is bcrypt's own base64 alphabet, unpadded.
+var bcryptB64 = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+// bcryptHash hashes password with x/crypto/bcrypt, which draws the salt from
+// crypto/rand. Its $2a$ output is relabelled $2b$: the variants differ only for
+// passwords over 255 bytes, and bcrypt stops at 72.
+func bcryptHash(password []byte, cost int) (string, error) {
+	if len(password) > 72 {
+		return "", fmt.Errorf("bcrypt passwords are limited to 72 bytes, got %d", len(password))
+	}
+	out, err := bcrypt.GenerateFromPassword(password, cost)
+	if err != nil {
+		return "", err
+	}
+	return "$2b$" + string(out[4:]), nil
+}
+
+// bcryptCompare checks password against a $2a$, $2b$ or $2y$ hash.
+func bcryptCompare(hash string, password []byte) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte("$2a$"+hash[4:]), password)
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,372 @@
+package passwdutil
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Algorithms lists the schemes Hash can produce.
+var Algorithms = []string{"bcrypt", "argon2id", "scrypt", "pbkdf2"}
+
+// Digests lists the PBKDF2 pseudo-random functions.
+var Digests = []string{"sha1", "sha256", "sha512"}
+
+var digests = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// Options holds the cost parameters for every algorithm; each one reads only its own.
+type Options struct {
+	Cost       int    // bcrypt: log2 of the key expansion rounds
+	Time       uint32 // argon2id: passes over memory
+	Memory     uint32 // argon2id: KiB
+	Threads    uint8  // argon2id: lanes
+	LogN       int    // scrypt: log2 of the CPU/memory cost N
+	R, P       int    // scrypt: block size and parallelism
+	Iterations int    // pbkdf2
+	Digest     string // pbkdf2: sha1|sha256|sha512
+	SaltLen    int    // bytes; bcrypt always uses 16
+	KeyLen     int    // bytes; bcrypt always produces 23
+}
+
+// DefaultOptions follows the OWASP password storage recommendations.
+func DefaultOptions() Options {
+	return Options{
+		Cost:       12,
+		Time:       2,
+		Memory:     19 * 1024,
+		Threads:    1,
+		LogN:       17,
+		R:          8,
+		P:          1,
+		Iterations: 600000,
+		Digest:     "sha256",
+		SaltLen:    16,
+		KeyLen:     32,
+	}
+}
+
+// Field is a decoded hash parameter, kept in display order.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Hashed is a parsed password hash.
+type Hashed struct {
+	Algorithm string
+	Params    []Field
+	Salt      []byte
+	Key       []byte
+	derive    func(password []byte) ([]byte, error)
+	compare   func(password []byte) (bool, error) // used instead of derive when set
+}
+
+// Verify reports whether password produces the stored key, comparing in constant time.
+func (h *Hashed) Verify(password []byte) (bool, error) {
+	if h.compare != nil {
+		return h.compare(password)
+	}
+	key, err := h.derive(password)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(key, h.Key) == 1, nil
+}
+
+// maxLen bounds --salt-len and --key-len; longer values buy nothing and a large
+// enough one exhausts memory.
+const maxLen = 1024
+
+// scryptRP reports whether r and p are within the limits scrypt.Key assumes: it
+// divides by both, and RFC 7914 requires r*p < 2^30.
+func scryptRP(r, p uint64) bool {
+	return r >= 1 && p >= 1 && r < 1<<30 && p < 1<<30 && r*p < 1<<30
+}
+
+// phc is the standard base64 alphabet without padding used by PHC strings.
+var phc = base64.RawStdEncoding
+
+// Hash hashes password with algo and a fresh salt read from r, and returns it as a
+// modular crypt (bcrypt) or PHC string. bcrypt draws its own salt from crypto/rand,
+// so it accepts no other r.
+func Hash(r io.Reader, algo string, password []byte, o Options) (string, error) {
+	if algo == "bcrypt" {
+		if o.Cost < 4 || o.Cost > 31 {
+			return "", fmt.Errorf("bcrypt cost must be between 4 and 31, got %d", o.Cost)
+		}
+		if r != rand.Reader {
+			return "", errors.New("bcrypt salts always come from crypto/rand; use another algorithm for seeded hashes")
+		}
+		return bcryptHash(password, o.Cost)
+	}
+	if o.SaltLen < 8 || o.SaltLen > maxLen {
+		return "", fmt.Errorf("salt must be between 8 and %d bytes, got %d", maxLen, o.SaltLen)
+	}
+	if o.KeyLen < 16 || o.KeyLen > maxLen {
+		return "", fmt.Errorf("key length must be between 16 and %d bytes, got %d", maxLen, o.KeyLen)
+	}
+	salt := make([]byte, o.SaltLen)
+	if _, err := io.ReadFull(r, salt); err != nil {
+		return "", err
+	}
+	switch algo {
+	case "argon2id":
+		if o.Time < 1 || o.Memory < 8*uint32(o.Threads) || o.Threads < 1 {
+			return "", fmt.Errorf("argon2id needs time >= 1, threads >= 1 and memory >= 8 KiB per thread")
+		}
+		key := argon2.IDKey(password, salt, o.Time, o.Memory, o.Threads, uint32(o.KeyLen))
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, o.Memory, o.Time, o.Threads,
+			phc.EncodeToString(salt), phc.EncodeToString(key)), nil
+	case "scrypt":
+		if o.LogN < 1 || o.LogN > 30 {
+			return "", fmt.Errorf("scrypt log2 N must be between 1 and 30, got %d", o.LogN)
+		}
+		if o.R < 1 || o.P < 1 || !scryptRP(uint64(o.R), uint64(o.P)) {
+			return "", fmt.Errorf("scrypt needs r >= 1, p >= 1 and r*p < 2^30, got r=%d, p=%d", o.R, o.P)
+		}
+		key, err := scrypt.Key(password, salt, 1<<o.LogN, o.R, o.P, o.KeyLen)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("$scrypt$ln=%d,r=%d,p=%d$%s$%s", o.LogN, o.R, o.P, phc.EncodeToString(salt), phc.EncodeToString(key)), nil
+	case "pbkdf2":
+		prf, ok := digests[o.Digest]
+		if !ok {
+			return "", fmt.Errorf("unknown pbkdf2 digest %q (want %s)", o.Digest, strings.Join(Digests, "|"))
+		}
+		if o.Iterations < 1 {
+			return "", fmt.Errorf("pbkdf2 iterations must be positive, got %d", o.Iterations)
+		}
+		key := pbkdf2.Key(password, salt, o.Iterations, o.KeyLen, prf)
+		return fmt.Sprintf("$pbkdf2-%s$i=%d,l=%d$%s$%s", o.Digest, o.Iterations, o.KeyLen, phc.EncodeToString(salt), phc.EncodeToString(key)), nil
+	default:
+		return "", fmt.Errorf("unknown algorithm %q (want %s)", algo, strings.Join(Algorithms, "|"))
+	}
+}
+
+// Parse decodes a bcrypt modular crypt string ($2a$, $2b$, $2y$) or a PHC string for
+// argon2id/argon2i, scrypt or pbkdf2-sha1/sha256/sha512. Passlib's variants, with
+// "." in place of "+" and bare pbkdf2 round counts, are accepted too.
+func Parse(s string) (*Hashed, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, "$")
+	if len(parts) < 3 || parts[0] != "" {
+		return nil, errors.New("not a modular crypt or PHC string (expected $id$...)")
+	}
+	id := parts[1]
+	switch {
+	case id == "2a" || id == "2b" || id == "2y":
+		return parseBcrypt(id, parts[2:])
+	case id == "argon2id" || id == "argon2i":
+		return parseArgon2(id, parts[2:])
+	case id == "scrypt":
+		return parseScrypt(parts[2:])
+	case strings.HasPrefix(id, "pbkdf2-"):
+		return parsePBKDF2(strings.TrimPrefix(id, "pbkdf2-"), parts[2:])
+	default:
+		return nil, fmt.Errorf("unsupported hash scheme $%s$", id)
+	}
+}
+
+func parseBcrypt(id string, parts []string) (*Hashed, error) {
+	if len(parts) != 2 || len(parts[1]) != 53 {
+		return nil, errors.New("malformed bcrypt hash (expected $2b$<cost>$<53 chars>)")
+	}
+	cost, err := strconv.Atoi(parts[0])
+	if err != nil || cost < 4 || cost > 31 {
+		return nil, fmt.Errorf("invalid bcrypt cost %q", parts[0])
+	}
+	salt, err := bcryptB64.DecodeString(parts[1][:22])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bcrypt salt: %w", err)
+	}
+	key, err := bcryptB64.DecodeString(parts[1][22:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bcrypt hash: %w", err)
+	}
+	return &Hashed{
+		Algorithm: "bcrypt",
+		Params: []Field{
+			{"variant", "$" + id + "$"},
+			{"cost", fmt.Sprintf("%d (%d rounds)", cost, uint64(1)<<cost)},
+		},
+		Salt: salt,
+		Key:  key,
+		compare: func(pw []byte) (bool, error) {
+			return bcryptCompare("$"+id+"$"+parts[0]+"$"+parts[1], pw)
+		},
+	}, nil
+}
+
+func parseArgon2(id string, parts []string) (*Hashed, error) {
+	if len(parts) != 4 || !strings.HasPrefix(parts[0], "v=") {
+		return nil, fmt.Errorf("malformed %s hash (expected $%s$v=19$m=..,t=..,p=..$salt$hash)", id, id)
+	}
+	if parts[0] != fmt.Sprintf("v=%d", argon2.Version) {
+		return nil, fmt.Errorf("unsupported argon2 version %s (only v=%d)", parts[0], argon2.Version)
+	}
+	p, err := phcParams(parts[1], "m", "t", "p")
+	if err != nil {
+		return nil, err
+	}
+	if p["t"] < 1 || p["p"] < 1 || p["p"] > 255 || p["m"] > 1<<32-1 {
+		return nil, fmt.Errorf("invalid argon2 parameters %q", parts[1])
+	}
+	salt, key, err := phcSaltKey(parts[2], parts[3])
+	if err != nil {
+		return nil, err
+	}
+	m, t, threads := uint32(p["m"]), uint32(p["t"]), uint8(p["p"])
+	kdf := argon2.IDKey
+	if id == "argon2i" {
+		kdf = argon2.Key
+	}
+	return &Hashed{
+		Algorithm: id,
+		Params: []Field{
+			{"version", strconv.Itoa(argon2.Version)},
+			{"memory", fmt.Sprintf("%d KiB", m)},
+			{"time", strconv.Itoa(int(t))},
+			{"threads", strconv.Itoa(int(threads))},
+		},
+		Salt: salt,
+		Key:  key,
+		derive: func(pw []byte) ([]byte, error) {
+			return kdf(pw, salt, t, m, threads, uint32(len(key))), nil
+		},
+	}, nil
+}
+
+func parseScrypt(parts []string) (*Hashed, error) {
+	if len(parts) != 3 {
+		return nil, errors.New("malformed scrypt hash (expected $scrypt$ln=..,r=..,p=..$salt$hash)")
+	}
+	p, err := phcParams(parts[0], "ln", "r", "p")
+	if err != nil {
+		return nil, err
+	}
+	if p["ln"] < 1 || p["ln"] > 30 {
+		return nil, fmt.Errorf("invalid scrypt ln %d", p["ln"])
+	}
+	if !scryptRP(p["r"], p["p"]) {
+		return nil, fmt.Errorf("invalid scrypt parameters %q", parts[0])
+	}
+	salt, key, err := phcSaltKey(parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+	ln, r, par := int(p["ln"]), int(p["r"]), int(p["p"])
+	return &Hashed{
+		Algorithm: "scrypt",
+		Params: []Field{
+			{"N", fmt.Sprintf("%d (ln=%d)", 1<<ln, ln)},
+			{"r", strconv.Itoa(r)},
+			{"p", strconv.Itoa(par)},
+		},
+		Salt: salt,
+		Key:  key,
+		derive: func(pw []byte) ([]byte, error) {
+			return scrypt.Key(pw, salt, 1<<ln, r, par, len(key))
+		},
+	}, nil
+}
+
+func parsePBKDF2(digest string, parts []string) (*Hashed, error) {
+	prf, ok := digests[digest]
+	if !ok {
+		return nil, fmt.Errorf("unsupported pbkdf2 digest %q", digest)
+	}
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed pbkdf2 hash (expected $pbkdf2-%s$i=..$salt$hash)", digest)
+	}
+	var iter uint64
+	if n, err := strconv.ParseUint(parts[0], 10, 32); err == nil {
+		iter = n // passlib: bare round count
+	} else {
+		p, err := phcParams(parts[0], "i")
+		if err != nil {
+			return nil, err
+		}
+		iter = p["i"]
+	}
+	if iter < 1 || iter > 1<<31-1 {
+		return nil, fmt.Errorf("invalid pbkdf2 iterations %d", iter)
+	}
+	salt, key, err := phcSaltKey(parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+	return &Hashed{
+		Algorithm: "pbkdf2",
+		Params: []Field{
+			{"digest", digest},
+			{"iterations", strconv.FormatUint(iter, 10)},
+		},
+		Salt: salt,
+		Key:  key,
+		derive: func(pw []byte) ([]byte, error) {
+			return pbkdf2.Key(pw, salt, int(iter), len(key), prf), nil
+		},
+	}, nil
+}
+
+// phcParams parses "k=v,k=v" requiring every name in want; unknown names such as
+// pbkdf2's "l" are ignored.
+func phcParams(s string, want ...string) (map[string]uint64, error) {
+	out := map[string]uint64{}
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid parameter %q", kv)
+		}
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %q", kv)
+		}
+		out[k] = n
+	}
+	for _, k := range want {
+		if _, ok := out[k]; !ok {
+			return nil, fmt.Errorf("missing parameter %q in %q", k, s)
+		}
+	}
+	return out, nil
+}
+
+func phcSaltKey(salt, key string) ([]byte, []byte, error) {
+	s, err := phcDecode(salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid salt: %w", err)
+	}
+	k, err := phcDecode(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid hash: %w", err)
+	}
+	if len(k) == 0 {
+		return nil, nil, errors.New("empty hash")
+	}
+	return s, k, nil
+}
+
+// phcDecode accepts unpadded standard base64 and passlib's "." for "+".
+func phcDecode(s string) ([]byte, error) {
+	s = strings.TrimRight(strings.ReplaceAll(s, ".", "+"), "=")
+	return phc.DecodeString(s)
+}
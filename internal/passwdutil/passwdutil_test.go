@@ -0,0 +1,118 @@
+package passwdutil
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestParseVerify_KnownHashes(t *testing.T) {
+	cases := []struct {
+		hash, password, algo string
+	}{
+		// from the argon2 reference implementation's README
+		{"$argon2i$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$RdescudvJCsgt3ub+b+dWRWJTmaaJObG", "password", "argon2i"},
+		{"$argon2id$v=19$m=65536,t=2,p=4$c29tZXNhbHQ$GpZ3sK/oH9p7VIiV56G/64Zo/8GaUw434IimaPqxwCo", "password", "argon2id"},
+		// passlib style: bare round count and "." for "+"
+		{"$pbkdf2-sha512$1000$c2FsdHNhbHRzYWx0c2FsdA$uHBiiPdIHgrbiMhoB2V/9a0plYfCgvbrBRDDFg1YzClF408xFBHqUitf7Em2Y8G.RZ42qB6ca3nzr1ef3VtwjA", "hunter2", "pbkdf2"},
+		{"$scrypt$ln=10,r=8,p=1$c2FsdHNhbHRzYWx0c2FsdA$v/uBvjpkrv4+RPlRbT7o/v0/ucpdIQIN3+rMqzxpxj4", "hunter2", "scrypt"},
+	}
+	for _, c := range cases {
+		h, err := Parse(c.hash)
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", c.hash, err)
+		}
+		if h.Algorithm != c.algo {
+			t.Errorf("algorithm = %s, want %s", h.Algorithm, c.algo)
+		}
+		if ok, err := h.Verify([]byte(c.password)); err != nil || !ok {
+			t.Errorf("%s: correct password rejected (%v)", c.algo, err)
+		}
+		if ok, _ := h.Verify([]byte(c.password + "x")); ok {
+			t.Errorf("%s: wrong password accepted", c.algo)
+		}
+	}
+}
+
+func TestHash_RoundTrip(t *testing.T) {
+	o := DefaultOptions()
+	o.Cost, o.Memory, o.LogN, o.Iterations = 4, 64, 4, 100
+	for _, algo := range Algorithms {
+		s, err := Hash(rand.Reader, algo, []byte("s3cret"), o)
+		if err != nil {
+			t.Fatalf("Hash(%s): %v", algo, err)
+		}
+		again, _ := Hash(rand.Reader, algo, []byte("s3cret"), o)
+		if s == again {
+			t.Errorf("%s: two hashes share a salt: %s", algo, s)
+		}
+		h, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", s, err)
+		}
+		if ok, err := h.Verify([]byte("s3cret")); err != nil || !ok {
+			t.Errorf("%s: round trip failed for %s (%v)", algo, s, err)
+		}
+	}
+}
+
+func TestHash_RejectsBadParameters(t *testing.T) {
+	for name, tweak := range map[string]func(*Options){
+		"scrypt r=0":   func(o *Options) { o.R = 0 },
+		"scrypt p=0":   func(o *Options) { o.P = 0 },
+		"scrypt r*p":   func(o *Options) { o.R, o.P = 1<<15, 1<<15 },
+		"huge key-len": func(o *Options) { o.KeyLen = 100000000000 },
+	} {
+		o := DefaultOptions()
+		o.LogN = 4
+		tweak(&o)
+		if _, err := Hash(rand.Reader, "scrypt", []byte("pw"), o); err == nil {
+			t.Errorf("%s: Hash should fail", name)
+		}
+	}
+}
+
+func TestBcrypt_Compatible(t *testing.T) {
+	s, err := Hash(rand.Reader, "bcrypt", []byte("hunter2"), Options{Cost: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(s, "$2b$05$") || len(s) != 60 {
+		t.Fatalf("unexpected bcrypt format %q", s)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(s), []byte("hunter2")); err != nil {
+		t.Fatalf("x/crypto/bcrypt rejects our hash: %v", err)
+	}
+	theirs, _ := bcrypt.GenerateFromPassword([]byte("hunter2"), 4)
+	h, err := Parse(strings.Replace(string(theirs), "$2a$", "$2y$", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := h.Verify([]byte("hunter2")); !ok {
+		t.Fatalf("x/crypto/bcrypt hash %s not verified", theirs)
+	}
+	if _, err := Hash(rand.Reader, "bcrypt", make([]byte, 73), Options{Cost: 4}); err == nil {
+		t.Fatal("expected an error for passwords over 72 bytes")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, s := range []string{
+		"plain",
+		"$md5$abc",
+		"$2b$99$abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz0",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=64,p=1$c2FsdA$aGFzaA",
+		"$pbkdf2-md5$i=10$c2FsdA$aGFzaA",
+		"$scrypt$ln=10,r=8,p=1$c2FsdA$",
+		"$scrypt$ln=4,r=0,p=1$c2FsdA$aGFzaA",
+		"$scrypt$ln=4,r=8,p=0$c2FsdA$aGFzaA",
+		"$scrypt$ln=4,r=32768,p=32768$c2FsdA$aGFzaA",
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("Parse(%q) should fail", s)
+		}
+	}
+}
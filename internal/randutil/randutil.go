@@ -0,0 +1,180 @@
+package randutil
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Bytes reads n random bytes from r.
+func Bytes(r io.Reader, n int) ([]byte, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("byte count must not be negative, got %d", n)
+	}
+	b := make([]byte, n)
+	_, err := io.ReadFull(r, b)
+	return b, err
+}
+
+// Uint64n returns a uniform value in [0, n) read from r, rejecting samples from the
+// biased tail so that every value is equally likely.
+func Uint64n(r io.Reader, n uint64) (uint64, error) {
+	if n == 0 {
+		return 0, errors.New("range must not be empty")
+	}
+	limit := math.MaxUint64 - math.MaxUint64%n
+	var b [8]byte
+	for {
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return 0, err
+		}
+		if v := binary.BigEndian.Uint64(b[:]); v < limit {
+			return v % n, nil
+		}
+	}
+}
+
+// IntRange returns a uniform value in [min, max].
+func IntRange(r io.Reader, min, max int64) (int64, error) {
+	if min > max {
+		return 0, fmt.Errorf("min %d is greater than max %d", min, max)
+	}
+	span := uint64(max) - uint64(min) + 1
+	if span == 0 { // the full int64 range: every 64-bit value is fair
+		var b [8]byte
+		_, err := io.ReadFull(r, b[:])
+		return int64(binary.BigEndian.Uint64(b[:])), err
+	}
+	v, err := Uint64n(r, span)
+	return min + int64(v), err
+}
+
+// Classes maps character class names to their members.
+var Classes = map[string]string{
+	"lower":   "abcdefghijklmnopqrstuvwxyz",
+	"upper":   "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"digits":  "0123456789",
+	"symbols": "!#$%&()*+,-./:;<=>?@[]^_{|}~",
+}
+
+// ClassNames lists Classes in their default order.
+var ClassNames = []string{"lower", "upper", "digits", "symbols"}
+
+// Ambiguous holds characters that are easily confused when read or typed.
+const Ambiguous = "Il1O0o|`'\""
+
+// Policy describes which characters a password may contain.
+type Policy struct {
+	Length           int
+	Classes          []string // names from Classes; empty means all
+	MinEach          int      // minimum characters drawn from every class
+	ExcludeAmbiguous bool
+	Exclude          string // further characters to leave out
+}
+
+// Password returns a password satisfying p together with its entropy estimate in
+// bits (length × log2 of the alphabet size, an upper bound once MinEach applies).
+func Password(r io.Reader, p Policy) (string, float64, error) {
+	names := p.Classes
+	if len(names) == 0 {
+		names = ClassNames
+	}
+	drop := p.Exclude
+	if p.ExcludeAmbiguous {
+		drop += Ambiguous
+	}
+	var sets []string
+	var all strings.Builder
+	for _, name := range names {
+		members, ok := Classes[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return "", 0, fmt.Errorf("unknown character class %q (use %s)", name, strings.Join(ClassNames, "|"))
+		}
+		members = strings.Map(func(c rune) rune {
+			if strings.ContainsRune(drop, c) || strings.ContainsRune(all.String(), c) {
+				return -1
+			}
+			return c
+		}, members)
+		if members == "" {
+			return "", 0, fmt.Errorf("character class %q is empty after exclusions", name)
+		}
+		sets = append(sets, members)
+		all.WriteString(members)
+	}
+	if p.Length <= 0 {
+		return "", 0, fmt.Errorf("password length must be positive, got %d", p.Length)
+	}
+	if p.MinEach*len(sets) > p.Length {
+		return "", 0, fmt.Errorf("length %d cannot hold %d characters from each of %d classes", p.Length, p.MinEach, len(sets))
+	}
+	pick := func(set string) (byte, error) {
+		i, err := Uint64n(r, uint64(len(set)))
+		return set[i], err
+	}
+	out := make([]byte, 0, p.Length)
+	for _, set := range sets {
+		for i := 0; i < p.MinEach; i++ {
+			c, err := pick(set)
+			if err != nil {
+				return "", 0, err
+			}
+			out = append(out, c)
+		}
+	}
+	for len(out) < p.Length {
+		c, err := pick(all.String())
+		if err != nil {
+			return "", 0, err
+		}
+		out = append(out, c)
+	}
+	// Fisher-Yates so the required characters do not sit at the front
+	for i := len(out) - 1; i > 0; i-- {
+		j, err := Uint64n(r, uint64(i+1))
+		if err != nil {
+			return "", 0, err
+		}
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out), float64(p.Length) * math.Log2(float64(all.Len())), nil
+}
+
+//go:embed eff_large_wordlist.txt
+var effLarge []byte
+
+// Words returns the EFF large diceware wordlist (7776 words, five dice per word).
+var Words = sync.OnceValue(func() []string {
+	words := make([]string, 0, 7776)
+	sc := bufio.NewScanner(bytes.NewReader(effLarge))
+	for sc.Scan() {
+		if _, word, ok := strings.Cut(sc.Text(), "\t"); ok {
+			words = append(words, word)
+		}
+	}
+	return words
+})
+
+// Passphrase returns n words chosen uniformly from Words and the entropy in bits.
+func Passphrase(r io.Reader, n int) ([]string, float64, error) {
+	if n <= 0 {
+		return nil, 0, fmt.Errorf("word count must be positive, got %d", n)
+	}
+	list := Words()
+	out := make([]string, n)
+	for i := range out {
+		j, err := Uint64n(r, uint64(len(list)))
+		if err != nil {
+			return nil, 0, err
+		}
+		out[i] = list[j]
+	}
+	return out, float64(n) * math.Log2(float64(len(list))), nil
+}
@@ -0,0 +1,66 @@
+package randutil
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestIntRange(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := map[int64]bool{}
+	for i := 0; i < 500; i++ {
+		v, err := IntRange(r, -2, 2)
+		if err != nil || v < -2 || v > 2 {
+			t.Fatalf("IntRange(-2, 2) = %d, %v", v, err)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 5 {
+		t.Fatalf("expected all 5 values, saw %v", seen)
+	}
+	if _, err := IntRange(r, 3, 1); err == nil {
+		t.Fatal("expected error for min > max")
+	}
+}
+
+func TestPassword(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		pw, bits, err := Password(r, Policy{Length: 8, Classes: []string{"lower", "digits"}, MinEach: 3, ExcludeAmbiguous: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pw) != 8 || strings.ContainsAny(pw, Ambiguous) || strings.Trim(pw, Classes["lower"]+Classes["digits"]) != "" {
+			t.Fatalf("password %q violates policy", pw)
+		}
+		digits := 0
+		for _, c := range pw {
+			if c >= '0' && c <= '9' {
+				digits++
+			}
+		}
+		if digits < 3 {
+			t.Fatalf("password %q has fewer than 3 digits", pw)
+		}
+		if bits != 40 { // 8 × log2(24 letters + 8 digits)
+			t.Fatalf("unexpected entropy %.2f", bits)
+		}
+	}
+	if _, _, err := Password(r, Policy{Length: 4, MinEach: 2}); err == nil {
+		t.Fatal("expected error when classes cannot fit")
+	}
+	if _, _, err := Password(r, Policy{Length: 4, Classes: []string{"emoji"}}); err == nil {
+		t.Fatal("expected error for unknown class")
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	if n := len(Words()); n != 7776 {
+		t.Fatalf("wordlist has %d words", n)
+	}
+	words, bits, err := Passphrase(rand.New(rand.NewSource(1)), 6)
+	if err != nil || len(words) != 6 || bits < 77.5 || bits > 77.6 {
+		t.Fatalf("Passphrase = %v, %.2f, %v", words, bits, err)
+	}
+}
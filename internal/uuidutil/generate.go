@@ -0,0 +1,199 @@
+package uuidutil
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Well-known name-based namespaces (RFC 9562 section 6.6).
+var (
+	NamespaceDNS  = MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceURL  = MustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceOID  = MustParse("6ba7b812-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceX500 = MustParse("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+)
+
+// MustParse is like Parse but panics on invalid input; meant for constants.
+func MustParse(s string) UUID {
+	u, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// ParseNamespace resolves dns, url, oid, x500 or a literal UUID.
+func ParseNamespace(s string) (UUID, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "dns":
+		return NamespaceDNS, nil
+	case "url":
+		return NamespaceURL, nil
+	case "oid":
+		return NamespaceOID, nil
+	case "x500":
+		return NamespaceX500, nil
+	}
+	u, err := Parse(s)
+	if err != nil {
+		return u, fmt.Errorf("invalid namespace %q (use dns|url|oid|x500|<uuid>)", s)
+	}
+	return u, nil
+}
+
+// NewV3 returns the MD5 name-based UUID of name within ns.
+func NewV3(ns UUID, name string) UUID {
+	return newHashed(md5.New(), 3, ns, name)
+}
+
+// NewV5 returns the SHA-1 name-based UUID of name within ns.
+func NewV5(ns UUID, name string) UUID {
+	return newHashed(sha1.New(), 5, ns, name)
+}
+
+func newHashed(h hash.Hash, version byte, ns UUID, name string) UUID {
+	h.Write(ns[:])
+	h.Write([]byte(name))
+	var u UUID
+	copy(u[:], h.Sum(nil))
+	u.setVersion(version)
+	return u
+}
+
+func (u *UUID) setVersion(v byte) {
+	u[6] = (u[6] & 0x0f) | v<<4
+	u[8] = (u[8] & 0x3f) | 0x80
+}
+
+// Generator creates random and time-based UUIDs. Time-based versions are strictly
+// increasing per Generator even when the clock does not advance between calls.
+type Generator struct {
+	Rand io.Reader
+	Now  func() time.Time
+
+	mu       sync.Mutex
+	lastV1   uint64 // last 60-bit Gregorian timestamp used by v1/v6
+	lastV7   uint64 // last 48-bit millisecond + 12-bit fraction used by v7
+	clockSeq uint16
+	node     [6]byte
+	hasNode  bool
+}
+
+// NewGenerator returns a Generator reading crypto/rand and the system clock.
+func NewGenerator() *Generator {
+	return &Generator{Rand: rand.Reader, Now: time.Now}
+}
+
+// New returns a UUID of a random or time-based version (1, 4, 6 or 7).
+func (g *Generator) New(version int) (UUID, error) {
+	switch version {
+	case 1:
+		return g.NewV1()
+	case 4:
+		return g.NewV4()
+	case 6:
+		return g.NewV6()
+	case 7:
+		return g.NewV7()
+	default:
+		return UUID{}, fmt.Errorf("version %d is not random or time-based (use 1, 4, 6 or 7)", version)
+	}
+}
+
+// NewV4 returns a random UUID.
+func (g *Generator) NewV4() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(g.Rand, u[:]); err != nil {
+		return u, err
+	}
+	u.setVersion(4)
+	return u, nil
+}
+
+// NewV7 returns a Unix-millisecond time-ordered UUID. The 12 bits after the
+// timestamp hold the sub-millisecond fraction (RFC 9562 method 3).
+func (g *Generator) NewV7() (UUID, error) {
+	var u UUID
+	if _, err := io.ReadFull(g.Rand, u[8:]); err != nil {
+		return u, err
+	}
+	t := g.Now()
+	ts := uint64(t.UnixMilli())<<12 | uint64(t.Nanosecond()%1e6)*4096/1e6
+	g.mu.Lock()
+	if ts <= g.lastV7 {
+		ts = g.lastV7 + 1
+	}
+	g.lastV7 = ts
+	g.mu.Unlock()
+	ms := ts >> 12
+	u[0], u[1], u[2], u[3], u[4], u[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+	u[6], u[7] = byte(ts>>8)&0x0f, byte(ts)
+	u.setVersion(7)
+	return u, nil
+}
+
+// NewV1 returns a Gregorian time-based UUID with a random multicast node ID.
+func (g *Generator) NewV1() (UUID, error) {
+	ts, err := g.gregorian()
+	if err != nil {
+		return UUID{}, err
+	}
+	var u UUID
+	u[0], u[1], u[2], u[3] = byte(ts>>24), byte(ts>>16), byte(ts>>8), byte(ts)
+	u[4], u[5] = byte(ts>>40), byte(ts>>32)
+	u[6], u[7] = byte(ts>>56), byte(ts>>48)
+	g.fillClockNode(&u)
+	u.setVersion(1)
+	return u, nil
+}
+
+// NewV6 returns a field-reordered, sortable variant of NewV1.
+func (g *Generator) NewV6() (UUID, error) {
+	ts, err := g.gregorian()
+	if err != nil {
+		return UUID{}, err
+	}
+	var u UUID
+	u[0], u[1], u[2], u[3] = byte(ts>>52), byte(ts>>44), byte(ts>>36), byte(ts>>28)
+	u[4], u[5] = byte(ts>>20), byte(ts>>12)
+	u[6], u[7] = byte(ts>>8), byte(ts)
+	g.fillClockNode(&u)
+	u.setVersion(6)
+	return u, nil
+}
+
+// gregorian returns the next 100ns tick since 1582-10-15, initialising the clock
+// sequence and node from Rand on first use.
+func (g *Generator) gregorian() (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.hasNode {
+		var b [8]byte
+		if _, err := io.ReadFull(g.Rand, b[:]); err != nil {
+			return 0, err
+		}
+		g.clockSeq = uint16(b[0])<<8 | uint16(b[1])
+		copy(g.node[:], b[2:])
+		g.node[0] |= 0x01 // multicast bit marks a random node (RFC 9562 section 6.10)
+		g.hasNode = true
+	}
+	t := g.Now()
+	ts := uint64(t.Unix()*1e7+int64(t.Nanosecond()/100)) + gregorianOffset
+	if ts <= g.lastV1 {
+		ts = g.lastV1 + 1
+	}
+	g.lastV1 = ts
+	return ts, nil
+}
+
+func (g *Generator) fillClockNode(u *UUID) {
+	u[8], u[9] = byte(g.clockSeq>>8), byte(g.clockSeq)
+	copy(u[10:], g.node[:])
+}
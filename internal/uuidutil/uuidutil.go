@@ -0,0 +1,153 @@
+package uuidutil
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"dt/internal/encutil"
+)
+
+// UUID is a 128-bit universally unique identifier (RFC 9562).
+type UUID [16]byte
+
+// gregorianOffset is the number of 100ns intervals between 1582-10-15 and the Unix epoch.
+const gregorianOffset = 0x01B21DD213814000
+
+// Parse accepts canonical (8-4-4-4-12), braced, urn:uuid: and 32-digit compact hex forms.
+func Parse(s string) (UUID, error) {
+	var u UUID
+	s = strings.TrimSpace(s)
+	if len(s) > 9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	}
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+	switch len(s) {
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return u, fmt.Errorf("invalid UUID %q: misplaced hyphens", s)
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	case 32:
+	default:
+		return u, fmt.Errorf("invalid UUID %q: expected 32 hex digits", s)
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, fmt.Errorf("invalid UUID %q: %w", s, err)
+	}
+	return u, nil
+}
+
+// String returns the canonical lower-case 8-4-4-4-12 form.
+func (u UUID) String() string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+// Formats lists the representations accepted by Format.
+var Formats = []string{"canonical", "upper", "braces", "urn", "hex", "base64", "base58"}
+
+// Format renders u in one of Formats.
+func (u UUID) Format(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", "canonical":
+		return u.String(), nil
+	case "upper":
+		return strings.ToUpper(u.String()), nil
+	case "braces":
+		return "{" + u.String() + "}", nil
+	case "urn":
+		return "urn:uuid:" + u.String(), nil
+	case "hex":
+		return hex.EncodeToString(u[:]), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(u[:]), nil
+	case "base58":
+		return encutil.Base58Encode(u[:]), nil
+	default:
+		return "", fmt.Errorf("unsupported UUID format %q (use %s)", format, strings.Join(Formats, "|"))
+	}
+}
+
+// versionNames describes the versions defined by RFC 9562.
+var versionNames = map[int]string{
+	1: "Gregorian time-based",
+	2: "DCE security",
+	3: "name-based MD5",
+	4: "random",
+	5: "name-based SHA-1",
+	6: "reordered Gregorian time-based",
+	7: "Unix time-ordered",
+	8: "custom",
+}
+
+// Describe names u's kind: "nil", "max" or its version's description.
+func (u UUID) Describe() string {
+	switch u {
+	case UUID{}:
+		return "nil"
+	case UUID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}:
+		return "max"
+	}
+	if name, ok := versionNames[u.Version()]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Version returns the version nibble (0-15).
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// Variant names the layout indicated by the variant bits.
+func (u UUID) Variant() string {
+	switch {
+	case u[8]&0x80 == 0:
+		return "NCS"
+	case u[8]&0xc0 == 0x80:
+		return "RFC 9562"
+	case u[8]&0xe0 == 0xc0:
+		return "Microsoft"
+	default:
+		return "future"
+	}
+}
+
+// Time returns the embedded timestamp for versions 1, 6 and 7.
+func (u UUID) Time() (time.Time, error) {
+	switch u.Version() {
+	case 1:
+		ts := uint64(u[6]&0x0f)<<56 | uint64(u[7])<<48 | uint64(u[4])<<40 | uint64(u[5])<<32 |
+			uint64(u[0])<<24 | uint64(u[1])<<16 | uint64(u[2])<<8 | uint64(u[3])
+		return gregorianTime(ts), nil
+	case 6:
+		ts := uint64(u[0])<<52 | uint64(u[1])<<44 | uint64(u[2])<<36 | uint64(u[3])<<28 |
+			uint64(u[4])<<20 | uint64(u[5])<<12 | uint64(u[6]&0x0f)<<8 | uint64(u[7])
+		return gregorianTime(ts), nil
+	case 7:
+		ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 | int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+		return time.UnixMilli(ms), nil
+	default:
+		return time.Time{}, errors.New("UUID version has no embedded timestamp")
+	}
+}
+
+// ClockSeq returns the 14-bit clock sequence of a version 1 or 6 UUID.
+func (u UUID) ClockSeq() int {
+	return int(u[8]&0x3f)<<8 | int(u[9])
+}
+
+// Node returns the 48-bit node of a version 1 or 6 UUID as a MAC-style string.
+func (u UUID) Node() string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", u[10], u[11], u[12], u[13], u[14], u[15])
+}
+
+func gregorianTime(ts uint64) time.Time {
+	d := int64(ts) - gregorianOffset
+	return time.Unix(d/1e7, (d%1e7)*100)
+}
@@ -0,0 +1,157 @@
+package uuidutil
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestParseForms(t *testing.T) {
+	want := "c232ab00-9414-11ec-b3c8-9f6bdeced846"
+	for _, in := range []string{
+		"C232AB00-9414-11EC-B3C8-9F6BDECED846",
+		"{c232ab00-9414-11ec-b3c8-9f6bdeced846}",
+		"urn:uuid:c232ab00-9414-11ec-b3c8-9f6bdeced846",
+		"c232ab00941411ecb3c89f6bdeced846",
+	} {
+		u, err := Parse(in)
+		if err != nil {
+			t.Fatalf("%s: %v", in, err)
+		}
+		if u.String() != want {
+			t.Fatalf("%s: got %s", in, u)
+		}
+	}
+	for _, bad := range []string{"", "c232ab00-9414-11ec-b3c8", "c232ab0009414-11ec-b3c8-9f6bdeced846", "z232ab00941411ecb3c89f6bdeced846"} {
+		if _, err := Parse(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+// Test vectors from RFC 9562 appendix A.
+func TestTimeVectors(t *testing.T) {
+	want := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	for in, ver := range map[string]int{
+		"C232AB00-9414-11EC-B3C8-9F6BDECED846": 1,
+		"1EC9414C-232A-6B00-B3C8-9F6BDECED846": 6,
+		"017F22E2-79B0-7CC3-98C4-DC0C0C07398F": 7,
+	} {
+		u, err := Parse(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Version() != ver || u.Variant() != "RFC 9562" {
+			t.Fatalf("%s: version %d variant %s", in, u.Version(), u.Variant())
+		}
+		got, err := u.Time()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(want) {
+			t.Fatalf("%s: got %s want %s", in, got.UTC(), want)
+		}
+	}
+	u, _ := Parse("C232AB00-9414-11EC-B3C8-9F6BDECED846")
+	if u.ClockSeq() != 0x33c8 || u.Node() != "9f:6b:de:ce:d8:46" {
+		t.Fatalf("unexpected clock_seq/node: %d %s", u.ClockSeq(), u.Node())
+	}
+	v4, _ := Parse("919108f7-52d1-4320-9bac-f847db4148a8")
+	if _, err := v4.Time(); err == nil {
+		t.Fatalf("expected no timestamp for v4")
+	}
+}
+
+func TestNameBasedVectors(t *testing.T) {
+	if got := NewV3(NamespaceDNS, "www.example.com").String(); got != "5df41881-3aed-3515-88a7-2f4a814cf09e" {
+		t.Fatalf("v3: got %s", got)
+	}
+	if got := NewV5(NamespaceDNS, "www.example.com").String(); got != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Fatalf("v5: got %s", got)
+	}
+	ns, err := ParseNamespace("URL")
+	if err != nil || ns != NamespaceURL {
+		t.Fatalf("unexpected namespace: %s %v", ns, err)
+	}
+}
+
+// The RFC 9562 appendix A examples, reproduced from their clock and random bits.
+func TestGeneratedVectors(t *testing.T) {
+	at := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	gregorian := &Generator{
+		Rand: bytes.NewReader([]byte{0x33, 0xc8, 0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}),
+		Now:  func() time.Time { return at },
+	}
+	v1, err := gregorian.NewV1()
+	if err != nil || v1.String() != "c232ab00-9414-11ec-b3c8-9f6bdeced846" {
+		t.Fatalf("v1: got %s %v", v1, err)
+	}
+	gregorian.lastV1 = 0
+	v6, err := gregorian.NewV6()
+	if err != nil || v6.String() != "1ec9414c-232a-6b00-b3c8-9f6bdeced846" {
+		t.Fatalf("v6: got %s %v", v6, err)
+	}
+	unix := &Generator{
+		Rand: bytes.NewReader([]byte{0x18, 0xc4, 0xdc, 0x0c, 0x0c, 0x07, 0x39, 0x8f}),
+		Now:  func() time.Time { return at.Add(797608 * time.Nanosecond) },
+	}
+	v7, err := unix.NewV7()
+	if err != nil || v7.String() != "017f22e2-79b0-7cc3-98c4-dc0c0c07398f" {
+		t.Fatalf("v7: got %s %v", v7, err)
+	}
+}
+
+func TestGeneratorMonotonic(t *testing.T) {
+	at := time.Now()
+	g := NewGenerator()
+	g.Now = func() time.Time { return at }
+	for _, v := range []int{1, 6, 7} {
+		prev, _ := g.New(v)
+		for i := 0; i < 100; i++ {
+			u, err := g.New(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Version() != v || u.Variant() != "RFC 9562" {
+				t.Fatalf("v%d: bad version/variant %s", v, u)
+			}
+			a, _ := prev.Time()
+			b, _ := u.Time()
+			if b.Before(a) || (v != 1 && bytes.Compare(u[:], prev[:]) <= 0) {
+				t.Fatalf("v%d not increasing: %s then %s", v, prev, u)
+			}
+			prev = u
+		}
+	}
+	v4, err := g.New(4)
+	if err != nil || v4.Version() != 4 {
+		t.Fatalf("v4: %s %v", v4, err)
+	}
+	if _, err := g.New(5); err == nil {
+		t.Fatalf("expected error for name-based version")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	u := MustParse("2ed6657d-e927-568b-95e1-2665a8aea6a2")
+	cases := map[string]string{
+		"upper":  "2ED6657D-E927-568B-95E1-2665A8AEA6A2",
+		"braces": "{2ed6657d-e927-568b-95e1-2665a8aea6a2}",
+		"urn":    "urn:uuid:2ed6657d-e927-568b-95e1-2665a8aea6a2",
+		"hex":    "2ed6657de927568b95e12665a8aea6a2",
+		"base64": "LtZlfeknVouV4SZlqK6mog==",
+		"base58": "6nTLogGvw2vmQjtATLqvLq",
+	}
+	for f, want := range cases {
+		got, err := u.Format(f)
+		if err != nil || got != want {
+			t.Fatalf("%s: got %q %v", f, got, err)
+		}
+	}
+	if _, err := u.Format("octal"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+	if (UUID{}).Describe() != "nil" || u.Describe() != "name-based SHA-1" {
+		t.Fatalf("unexpected descriptions")
+	}
+}